@@ -8,8 +8,19 @@ data "gdp-middleware-helper_postgres_role_check" "example" {
   db_name   = "postgres"
   ssl_mode  = "disable"
   role_name = "my_terraform_role"
+
+  privileges_database = "postgres"
+  privileges_schema   = "public"
 }
 
 output "role_exists" {
   value = data.gdp-middleware-helper_postgres_role_check.example.exists
-}
\ No newline at end of file
+}
+
+output "role_is_superuser" {
+  value = data.gdp-middleware-helper_postgres_role_check.example.superuser
+}
+
+output "role_member_of" {
+  value = data.gdp-middleware-helper_postgres_role_check.example.member_of
+}
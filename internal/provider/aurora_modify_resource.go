@@ -9,9 +9,9 @@ import (
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
-	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/rds"
 	"github.com/aws/aws-sdk-go-v2/service/rds/types"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -33,7 +33,7 @@ func NewAuroraModifyResource() resource.Resource {
 
 // AuroraModifyResource defines the resource implementation.
 type AuroraModifyResource struct {
-	client *rds.Client
+	providerData *providerData
 }
 
 // AuroraModifyResourceModel describes the resource data model.
@@ -45,6 +45,7 @@ type AuroraModifyResourceModel struct {
 	ApplyImmediately      frameworktypes.Bool   `tfsdk:"apply_immediately"`
 	LastModifiedTime      frameworktypes.String `tfsdk:"last_modified_time"`
 	ID                    frameworktypes.String `tfsdk:"id"`
+	Timeouts              timeouts.Value        `tfsdk:"timeouts"`
 }
 
 func (r *AuroraModifyResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -99,6 +100,12 @@ func (r *AuroraModifyResource) Schema(ctx context.Context, req resource.SchemaRe
 				},
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Update: true,
+			}),
+		},
 	}
 }
 
@@ -110,14 +117,13 @@ func (r *AuroraModifyResource) Configure(ctx context.Context, req resource.Confi
 		return
 	}
 
-	// Create AWS config and RDS client
-	awsCfg, err := config.LoadDefaultConfig(ctx)
-	if err != nil {
-		resp.Diagnostics.AddError("Unable to load AWS SDK config", fmt.Sprintf("Unable to load AWS SDK config: %s", err))
+	// Keep the provider data, RDS clients are built per region on first use
+	pd := providerDataFrom(req.ProviderData, &resp.Diagnostics)
+	if pd == nil {
 		return
 	}
 
-	r.client = rds.NewFromConfig(awsCfg)
+	r.providerData = pd
 }
 
 func (r *AuroraModifyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -129,21 +135,22 @@ func (r *AuroraModifyResource) Create(ctx context.Context, req resource.CreateRe
 		return
 	}
 
-	// If region is specified, update the AWS config
-	var client *rds.Client
-	if !data.Region.IsNull() {
-		tflog.Debug(ctx, "configuring client with region")
-		awsCfg, err := config.LoadDefaultConfig(ctx, config.WithRegion(data.Region.ValueString()))
-		if err != nil {
-			resp.Diagnostics.AddError("Unable to load AWS SDK config", fmt.Sprintf("Unable to load AWS SDK config with region %s: %s", data.Region.ValueString(), err))
-			return
-		}
-		client = rds.NewFromConfig(awsCfg)
-	} else {
-		tflog.Debug(ctx, "using default client")
-		client = r.client
+	if !r.providerData.configured(&resp.Diagnostics) {
+		return
+	}
+
+	timeout, diags := data.Timeouts.Create(ctx, defaultWaitTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	// Get the cached client for the requested region
+	client := r.providerData.rdsClient(data.Region.ValueString())
+
 	// Prepare modify input
 	input := &rds.ModifyDBClusterInput{
 		DBClusterIdentifier: aws.String(data.ClusterIdentifier.ValueString()),
@@ -191,7 +198,7 @@ func (r *AuroraModifyResource) Create(ctx context.Context, req resource.CreateRe
 	}
 
 	tflog.Info(ctx, "Waiting for Aurora cluster to become available after modification")
-	err = waiter.Wait(ctx, waitInput, 30*time.Minute)
+	err = waiter.Wait(ctx, waitInput, timeout)
 	if err != nil {
 		resp.Diagnostics.AddError("Error waiting for Aurora cluster to become available", fmt.Sprintf("Could not confirm Aurora cluster availability: %s", err))
 		return
@@ -215,21 +222,13 @@ func (r *AuroraModifyResource) Read(ctx context.Context, req resource.ReadReques
 		return
 	}
 
-	// If region is specified, update the AWS config
-	var client *rds.Client
-	if !data.Region.IsNull() {
-		tflog.Debug(ctx, "configuring client with region")
-		awsCfg, err := config.LoadDefaultConfig(ctx, config.WithRegion(data.Region.ValueString()))
-		if err != nil {
-			resp.Diagnostics.AddError("Unable to load AWS SDK config", fmt.Sprintf("Unable to load AWS SDK config with region %s: %s", data.Region.ValueString(), err))
-			return
-		}
-		client = rds.NewFromConfig(awsCfg)
-	} else {
-		tflog.Debug(ctx, "using default client")
-		client = r.client
+	if !r.providerData.configured(&resp.Diagnostics) {
+		return
 	}
 
+	// Get the cached client for the requested region
+	client := r.providerData.rdsClient(data.Region.ValueString())
+
 	// Describe the Aurora cluster to get current state
 	input := &rds.DescribeDBClustersInput{
 		DBClusterIdentifier: aws.String(data.ClusterIdentifier.ValueString()),
@@ -289,21 +288,22 @@ func (r *AuroraModifyResource) Update(ctx context.Context, req resource.UpdateRe
 		return
 	}
 
-	// If region is specified, update the AWS config
-	var client *rds.Client
-	if !data.Region.IsNull() {
-		tflog.Debug(ctx, "configuring client with region")
-		awsCfg, err := config.LoadDefaultConfig(ctx, config.WithRegion(data.Region.ValueString()))
-		if err != nil {
-			resp.Diagnostics.AddError("Unable to load AWS SDK config", fmt.Sprintf("Unable to load AWS SDK config with region %s: %s", data.Region.ValueString(), err))
-			return
-		}
-		client = rds.NewFromConfig(awsCfg)
-	} else {
-		tflog.Debug(ctx, "using default client")
-		client = r.client
+	if !r.providerData.configured(&resp.Diagnostics) {
+		return
 	}
 
+	timeout, diags := data.Timeouts.Update(ctx, defaultWaitTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	// Get the cached client for the requested region
+	client := r.providerData.rdsClient(data.Region.ValueString())
+
 	// Prepare modify input
 	input := &rds.ModifyDBClusterInput{
 		DBClusterIdentifier: aws.String(data.ClusterIdentifier.ValueString()),
@@ -351,7 +351,7 @@ func (r *AuroraModifyResource) Update(ctx context.Context, req resource.UpdateRe
 	}
 
 	tflog.Debug(ctx, "Waiting for Aurora cluster to become available after modification")
-	err = waiter.Wait(ctx, waitInput, 30*time.Minute)
+	err = waiter.Wait(ctx, waitInput, timeout)
 	if err != nil {
 		resp.Diagnostics.AddError("Error waiting for Aurora cluster to become available", fmt.Sprintf("Could not confirm Aurora cluster availability: %s", err))
 		return
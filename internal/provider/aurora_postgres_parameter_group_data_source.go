@@ -8,7 +8,6 @@ import (
 	"fmt"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
-	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/rds"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
@@ -26,7 +25,7 @@ func NewAuroraPostgresParameterGroupDataSource() datasource.DataSource {
 
 // AuroraPostgresParameterGroupDataSource defines the data source implementation.
 type AuroraPostgresParameterGroupDataSource struct {
-	client *rds.Client
+	providerData *providerData
 }
 
 // AuroraPostgresParameterGroupDataSourceModel describes the data source data model.
@@ -36,6 +35,8 @@ type AuroraPostgresParameterGroupDataSourceModel struct {
 	ParameterGroup    types.String `tfsdk:"parameter_group"`
 	FamilyName        types.String `tfsdk:"family_name"`
 	Description       types.String `tfsdk:"description"`
+	Names             types.List   `tfsdk:"names"`
+	Parameters        types.List   `tfsdk:"parameters"`
 	ID                types.String `tfsdk:"id"`
 }
 
@@ -69,6 +70,8 @@ func (d *AuroraPostgresParameterGroupDataSource) Schema(ctx context.Context, req
 				MarkdownDescription: "Aurora PostgreSQL parameter group description",
 				Computed:            true,
 			},
+			"names":      parameterNamesAttribute("`shared_preload_libraries` and `pgaudit.log`"),
+			"parameters": parametersAttribute(),
 			"id": schema.StringAttribute{
 				Computed:            true,
 				MarkdownDescription: "Identifier of the data source",
@@ -80,15 +83,19 @@ func (d *AuroraPostgresParameterGroupDataSource) Schema(ctx context.Context, req
 func (d *AuroraPostgresParameterGroupDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
 	tflog.Info(ctx, "Configuring Aurora PostgreSQL parameter group data source")
 
-	// Create AWS config and RDS client with default configuration
+	// If provider is not configured, return
+	if req.ProviderData == nil {
+		return
+	}
+
+	// Keep the provider data, RDS clients are built per region on first use
 	// Region can be overridden per-resource in the Read method
-	awsCfg, err := config.LoadDefaultConfig(ctx)
-	if err != nil {
-		resp.Diagnostics.AddError("Unable to load AWS SDK config", fmt.Sprintf("Unable to load AWS SDK config: %s", err))
+	pd := providerDataFrom(req.ProviderData, &resp.Diagnostics)
+	if pd == nil {
 		return
 	}
 
-	d.client = rds.NewFromConfig(awsCfg)
+	d.providerData = pd
 }
 
 func (d *AuroraPostgresParameterGroupDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -100,21 +107,13 @@ func (d *AuroraPostgresParameterGroupDataSource) Read(ctx context.Context, req d
 		return
 	}
 
-	// If region is specified, update the AWS config
-	var client *rds.Client
-	if !data.Region.IsNull() {
-		tflog.Debug(ctx, "configuring client with region")
-		awsCfg, err := config.LoadDefaultConfig(ctx, config.WithRegion(data.Region.ValueString()))
-		if err != nil {
-			resp.Diagnostics.AddError("Unable to load AWS SDK config", fmt.Sprintf("Unable to load AWS SDK config with region %s: %s", data.Region.ValueString(), err))
-			return
-		}
-		client = rds.NewFromConfig(awsCfg)
-	} else {
-		tflog.Debug(ctx, "using default client")
-		client = d.client
+	if !d.providerData.configured(&resp.Diagnostics) {
+		return
 	}
 
+	// Get the cached client for the requested region
+	client := d.providerData.rdsClient(data.Region.ValueString())
+
 	// Get Aurora PostgreSQL cluster information
 	input := &rds.DescribeDBClustersInput{DBClusterIdentifier: aws.String(data.ClusterIdentifier.ValueString())}
 
@@ -160,5 +159,21 @@ func (d *AuroraPostgresParameterGroupDataSource) Read(ctx context.Context, req d
 	data.Description = types.StringValue(*pgResp.DBClusterParameterGroups[0].Description)
 	data.ID = types.StringValue(data.ClusterIdentifier.ValueString())
 
+	// Read the parameter values, restricted to names when set
+	names := parameterNames(ctx, data.Names, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	parameters, err := rdsClusterParameterValues(ctx, client, data.ParameterGroup.ValueString(), names)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to describe DB cluster parameters", fmt.Sprintf("Error describing parameters of %s: %s", data.ParameterGroup.ValueString(), err))
+		return
+	}
+	data.Parameters = parametersListValue(ctx, parameters, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -5,16 +5,20 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
-	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/rds"
+	rdstypes "github.com/aws/aws-sdk-go-v2/service/rds/types"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
@@ -23,6 +27,7 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces
 var _ resource.Resource = &AuroraRebootResource{}
 var _ resource.ResourceWithImportState = &AuroraRebootResource{}
+var _ resource.ResourceWithModifyPlan = &AuroraRebootResource{}
 
 func NewAuroraRebootResource() resource.Resource {
 	return &AuroraRebootResource{}
@@ -30,16 +35,24 @@ func NewAuroraRebootResource() resource.Resource {
 
 // AuroraRebootResource defines the resource implementation.
 type AuroraRebootResource struct {
-	client *rds.Client
+	providerData *providerData
 }
 
 // AuroraRebootResourceModel describes the resource data model.
 type AuroraRebootResourceModel struct {
-	ClusterIdentifier types.String `tfsdk:"cluster_identifier"`
-	Region            types.String `tfsdk:"region"`
-	ForceFailover     types.Bool   `tfsdk:"force_failover"`
-	LastRebootTime    types.String `tfsdk:"last_reboot_time"`
-	ID                types.String `tfsdk:"id"`
+	ClusterIdentifier      types.String   `tfsdk:"cluster_identifier"`
+	Region                 types.String   `tfsdk:"region"`
+	ForceFailover          types.Bool     `tfsdk:"force_failover"`
+	FailoverTargetInstance types.String   `tfsdk:"failover_target_instance"`
+	OnlyIfPendingReboot    types.Bool     `tfsdk:"only_if_pending_reboot"`
+	Strategy               types.String   `tfsdk:"strategy"`
+	LastRebootTime         types.String   `tfsdk:"last_reboot_time"`
+	RebootRequired         types.Bool     `tfsdk:"reboot_required"`
+	PendingModifications   types.Bool     `tfsdk:"pending_modifications"`
+	WriterBefore           types.String   `tfsdk:"writer_before"`
+	WriterAfter            types.String   `tfsdk:"writer_after"`
+	ID                     types.String   `tfsdk:"id"`
+	Timeouts               timeouts.Value `tfsdk:"timeouts"`
 }
 
 func (r *AuroraRebootResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -64,13 +77,45 @@ func (r *AuroraRebootResource) Schema(ctx context.Context, req resource.SchemaRe
 				Optional:            true,
 			},
 			"force_failover": schema.BoolAttribute{
-				MarkdownDescription: "When true, the reboot is conducted through a MultiAZ failover",
+				MarkdownDescription: "When true, the cluster fails over to a reader before the writer is rebooted",
+				Optional:            true,
+			},
+			"failover_target_instance": schema.StringAttribute{
+				MarkdownDescription: "Reader instance promoted to writer when the cluster fails over, with `force_failover` or the `rolling` strategy. " +
+					"Defaults to the reader with the lowest promotion tier, then the largest instance class",
+				Optional: true,
+			},
+			"strategy": schema.StringAttribute{
+				MarkdownDescription: "How the cluster is rebooted: `rolling` reboots the readers one at a time and waits for each to be available, then fails over to a reader and reboots the old writer. " +
+					"`parallel` reboots every instance at once, or fails over when `force_failover` is set. `writer_only` reboots just the writer, after failing over to a reader when `force_failover` is set. Defaults to `parallel`",
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString(clusterRebootParallel),
+			},
+			"only_if_pending_reboot": schema.BoolAttribute{
+				MarkdownDescription: "When true, the cluster is only rebooted when the cluster parameter group of a member or the parameter group of an instance is in `pending-reboot` state",
 				Optional:            true,
 			},
 			"last_reboot_time": schema.StringAttribute{
 				MarkdownDescription: "Timestamp of the last reboot operation",
 				Computed:            true,
 			},
+			"reboot_required": schema.BoolAttribute{
+				MarkdownDescription: "Whether a cluster or instance parameter group of the cluster is in `pending-reboot` state. When true, the next plan reboots the cluster again",
+				Computed:            true,
+			},
+			"pending_modifications": schema.BoolAttribute{
+				MarkdownDescription: "Whether the cluster or one of its instances reports pending modified values, such as an instance class or engine version change waiting for the maintenance window. Informational only, a reboot does not apply them",
+				Computed:            true,
+			},
+			"writer_before": schema.StringAttribute{
+				MarkdownDescription: "Writer instance of the cluster before the last reboot",
+				Computed:            true,
+			},
+			"writer_after": schema.StringAttribute{
+				MarkdownDescription: "Writer instance of the cluster after the last reboot",
+				Computed:            true,
+			},
 			"id": schema.StringAttribute{
 				Computed:            true,
 				MarkdownDescription: "Identifier of the resource",
@@ -79,6 +124,12 @@ func (r *AuroraRebootResource) Schema(ctx context.Context, req resource.SchemaRe
 				},
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Update: true,
+			}),
+		},
 	}
 }
 
@@ -90,14 +141,13 @@ func (r *AuroraRebootResource) Configure(ctx context.Context, req resource.Confi
 		return
 	}
 
-	// Create AWS config and RDS client
-	awsCfg, err := config.LoadDefaultConfig(ctx)
-	if err != nil {
-		resp.Diagnostics.AddError("Unable to load AWS SDK config", fmt.Sprintf("Unable to load AWS SDK config: %s", err))
+	// Keep the provider data, RDS clients are built per region on first use
+	pd := providerDataFrom(req.ProviderData, &resp.Diagnostics)
+	if pd == nil {
 		return
 	}
 
-	r.client = rds.NewFromConfig(awsCfg)
+	r.providerData = pd
 }
 
 func (r *AuroraRebootResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -109,160 +159,114 @@ func (r *AuroraRebootResource) Create(ctx context.Context, req resource.CreateRe
 		return
 	}
 
-	// If region is specified, update the AWS config
-	var client *rds.Client
-	if !data.Region.IsNull() {
-		tflog.Debug(ctx, "configuring client with region")
-		awsCfg, err := config.LoadDefaultConfig(ctx, config.WithRegion(data.Region.ValueString()))
-		if err != nil {
-			resp.Diagnostics.AddError("Unable to load AWS SDK config", fmt.Sprintf("Unable to load AWS SDK config with region %s: %s", data.Region.ValueString(), err))
-			return
-		}
-		client = rds.NewFromConfig(awsCfg)
-	} else {
-		tflog.Debug(ctx, "using default client")
-		client = r.client
-	}
-
-	// Get the list of instances in the cluster to determine reboot strategy
-	describeInput := &rds.DescribeDBClustersInput{
-		DBClusterIdentifier: aws.String(data.ClusterIdentifier.ValueString()),
+	if !r.providerData.configured(&resp.Diagnostics) {
+		return
 	}
 
-	describeResult, err := client.DescribeDBClusters(ctx, describeInput)
-	if err != nil {
-		resp.Diagnostics.AddError("Error describing Aurora cluster", fmt.Sprintf("Could not describe Aurora cluster: %s", err))
+	if err := checkClusterRebootStrategy(data.Strategy.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("strategy"), "Invalid reboot strategy", err.Error())
 		return
 	}
 
-	if len(describeResult.DBClusters) == 0 {
-		resp.Diagnostics.AddError("Aurora cluster not found", fmt.Sprintf("No Aurora cluster found with identifier: %s", data.ClusterIdentifier.ValueString()))
+	timeout, diags := data.Timeouts.Create(ctx, defaultWaitTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	cluster := describeResult.DBClusters[0]
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
 
-	// Count total instances in the cluster
-	instanceCount := len(cluster.DBClusterMembers)
-
-	// Check if there are multiple instances (writer + reader)
-	hasReaderInstance := false
-	var readerInstanceID *string
-	for _, member := range cluster.DBClusterMembers {
-		if !*member.IsClusterWriter {
-			hasReaderInstance = true
-			readerInstanceID = member.DBInstanceIdentifier
-			break
-		}
+	// Stays null when the reboot is skipped
+	data.LastRebootTime = types.StringNull()
+
+	r.reboot(ctx, &data, timeout, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	// Only use failover if:
-	// 1. There are at least 2 instances in the cluster
-	// 2. There's a reader instance available
-	// 3. force_failover is explicitly set to true
-	if instanceCount >= 2 && hasReaderInstance && !data.ForceFailover.IsNull() && data.ForceFailover.ValueBool() {
-		// Prepare failover input
-		input := &rds.FailoverDBClusterInput{
-			DBClusterIdentifier:        aws.String(data.ClusterIdentifier.ValueString()),
-			TargetDBInstanceIdentifier: readerInstanceID,
-		}
+	data.ID = types.StringValue(data.ClusterIdentifier.ValueString())
 
-		tflog.Debug(ctx, "Failing over Aurora cluster", map[string]interface{}{
-			"cluster_identifier": data.ClusterIdentifier.ValueString(),
-			"target_instance":    *readerInstanceID,
-			"instance_count":     instanceCount,
-		})
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
 
-		// Failover the Aurora cluster
-		_, err = client.FailoverDBCluster(ctx, input)
-		if err != nil {
-			resp.Diagnostics.AddError("Error failing over Aurora cluster", fmt.Sprintf("Could not failover Aurora cluster: %s", err))
-			return
-		}
-	} else {
-		// Reboot each instance in the cluster individually
-		tflog.Debug(ctx, "Rebooting Aurora cluster instances individually", map[string]interface{}{
-			"cluster_identifier": data.ClusterIdentifier.ValueString(),
-			"instance_count":     instanceCount,
-			"reason":             "Single instance cluster or force_failover not enabled",
-		})
+func (r *AuroraRebootResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data AuroraRebootResourceModel
 
-		for _, member := range cluster.DBClusterMembers {
-			rebootInput := &rds.RebootDBInstanceInput{
-				DBInstanceIdentifier: member.DBInstanceIdentifier,
-			}
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-			// Don't set ForceFailover for single-instance clusters
-			if instanceCount >= 2 && !data.ForceFailover.IsNull() {
-				rebootInput.ForceFailover = aws.Bool(data.ForceFailover.ValueBool())
-			}
+	if !r.providerData.configured(&resp.Diagnostics) {
+		return
+	}
 
-			tflog.Debug(ctx, "Rebooting instance", map[string]interface{}{
-				"instance_identifier": *member.DBInstanceIdentifier,
-			})
+	// Get the cached client for the requested region
+	client := r.providerData.rdsClient(data.Region.ValueString())
 
-			_, err = client.RebootDBInstance(ctx, rebootInput)
-			if err != nil {
-				resp.Diagnostics.AddError("Error rebooting Aurora instance", fmt.Sprintf("Could not reboot Aurora instance %s: %s", *member.DBInstanceIdentifier, err))
-				return
-			}
-		}
+	// Check if the Aurora cluster exists and whether it is waiting for a reboot
+	cluster, err := describeAuroraCluster(ctx, client, data.ClusterIdentifier.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading Aurora cluster", fmt.Sprintf("Could not read Aurora cluster: %s", err))
+		return
 	}
 
-	// Wait for the cluster to become available again
-	waiter := rds.NewDBClusterAvailableWaiter(client)
-	waitInput := &rds.DescribeDBClustersInput{
-		DBClusterIdentifier: aws.String(data.ClusterIdentifier.ValueString()),
+	if cluster == nil {
+		tflog.Warn(ctx, "Aurora cluster not found, removing reboot resource from state", map[string]interface{}{
+			"cluster_identifier": data.ClusterIdentifier.ValueString(),
+		})
+		resp.State.RemoveResource(ctx)
+		return
 	}
 
-	tflog.Info(ctx, "Waiting for Aurora cluster to become available")
-	err = waiter.Wait(ctx, waitInput, 30*time.Minute)
+	pending, err := auroraPendingReboot(ctx, client, *cluster)
 	if err != nil {
-		resp.Diagnostics.AddError("Error waiting for Aurora cluster to become available", fmt.Sprintf("Could not confirm Aurora cluster availability: %s", err))
+		resp.Diagnostics.AddError("Error reading Aurora cluster instances", fmt.Sprintf("Could not read Aurora cluster instances: %s", err))
 		return
 	}
+	data.RebootRequired = types.BoolValue(pending.required())
+	data.PendingModifications = types.BoolValue(pending.Modifications)
 
-	// Set computed values
-	currentTime := time.Now().Format(time.RFC3339)
-	data.LastRebootTime = types.StringValue(currentTime)
-	data.ID = types.StringValue(data.ClusterIdentifier.ValueString())
-
-	// Save data into Terraform state
+	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
-func (r *AuroraRebootResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
-	var data AuroraRebootResourceModel
+func (r *AuroraRebootResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state AuroraRebootResourceModel
 
-	// Read Terraform prior state data into the model
-	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	// Read Terraform plan and prior state data into the models
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	// If region is specified, update the AWS config
-	var client *rds.Client
-	if !data.Region.IsNull() {
-		tflog.Debug(ctx, "configuring client with region")
-		awsCfg, err := config.LoadDefaultConfig(ctx, config.WithRegion(data.Region.ValueString()))
-		if err != nil {
-			resp.Diagnostics.AddError("Unable to load AWS SDK config", fmt.Sprintf("Unable to load AWS SDK config with region %s: %s", data.Region.ValueString(), err))
-			return
-		}
-		client = rds.NewFromConfig(awsCfg)
-	} else {
-		tflog.Debug(ctx, "using default client")
-		client = r.client
+	if !r.providerData.configured(&resp.Diagnostics) {
+		return
 	}
 
-	// Check if the Aurora cluster exists
-	input := &rds.DescribeDBClustersInput{
-		DBClusterIdentifier: aws.String(data.ClusterIdentifier.ValueString()),
+	if err := checkClusterRebootStrategy(data.Strategy.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("strategy"), "Invalid reboot strategy", err.Error())
+		return
 	}
 
-	_, err := client.DescribeDBClusters(ctx, input)
-	if err != nil {
-		resp.Diagnostics.AddError("Error reading Aurora cluster", fmt.Sprintf("Could not read Aurora cluster: %s", err))
+	timeout, diags := data.Timeouts.Update(ctx, defaultWaitTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	// Kept when the reboot is skipped
+	data.LastRebootTime = state.LastRebootTime
+
+	r.reboot(ctx, &data, timeout, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
@@ -270,96 +274,158 @@ func (r *AuroraRebootResource) Read(ctx context.Context, req resource.ReadReques
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
-func (r *AuroraRebootResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var data AuroraRebootResourceModel
+// ModifyPlan plans a reboot when the last refresh found changes waiting for one
+func (r *AuroraRebootResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to compare on create or destroy
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
 
-	// Read Terraform plan data into the model
-	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	var state, plan AuroraRebootResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	// If region is specified, update the AWS config
-	var client *rds.Client
-	if !data.Region.IsNull() {
-		tflog.Debug(ctx, "configuring client with region")
-		awsCfg, err := config.LoadDefaultConfig(ctx, config.WithRegion(data.Region.ValueString()))
-		if err != nil {
-			resp.Diagnostics.AddError("Unable to load AWS SDK config", fmt.Sprintf("Unable to load AWS SDK config with region %s: %s", data.Region.ValueString(), err))
-			return
-		}
-		client = rds.NewFromConfig(awsCfg)
-	} else {
-		tflog.Debug(ctx, "using default client")
-		client = r.client
+	if !state.RebootRequired.ValueBool() {
+		return
 	}
 
+	plan.RebootRequired = types.BoolUnknown()
+	plan.PendingModifications = types.BoolUnknown()
+	plan.LastRebootTime = types.StringUnknown()
+	plan.WriterBefore = types.StringUnknown()
+	plan.WriterAfter = types.StringUnknown()
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+}
+
+// reboot reboots or fails over the cluster, waits for it to become available again and records the outcome in data.
+// With only_if_pending_reboot the reboot is skipped when no parameter group is pending-reboot.
+func (r *AuroraRebootResource) reboot(ctx context.Context, data *AuroraRebootResourceModel, timeout time.Duration, diags *diag.Diagnostics) {
+	// Get the cached client for the requested region
+	client := r.providerData.rdsClient(data.Region.ValueString())
+	clusterID := data.ClusterIdentifier.ValueString()
+
 	// Get the list of instances in the cluster to determine reboot strategy
-	describeInput := &rds.DescribeDBClustersInput{
-		DBClusterIdentifier: aws.String(data.ClusterIdentifier.ValueString()),
+	cluster, err := describeAuroraCluster(ctx, client, clusterID)
+	if err != nil {
+		diags.AddError("Error describing Aurora cluster", fmt.Sprintf("Could not describe Aurora cluster: %s", err))
+		return
+	}
+
+	if cluster == nil {
+		diags.AddError("Aurora cluster not found", fmt.Sprintf("No Aurora cluster found with identifier: %s", clusterID))
+		return
 	}
 
-	describeResult, err := client.DescribeDBClusters(ctx, describeInput)
+	instances, err := describeAuroraClusterInstances(ctx, client, clusterID)
 	if err != nil {
-		resp.Diagnostics.AddError("Error describing Aurora cluster", fmt.Sprintf("Could not describe Aurora cluster: %s", err))
+		diags.AddError("Error reading Aurora cluster instances", fmt.Sprintf("Could not read Aurora cluster instances: %s", err))
 		return
 	}
 
-	if len(describeResult.DBClusters) == 0 {
-		resp.Diagnostics.AddError("Aurora cluster not found", fmt.Sprintf("No Aurora cluster found with identifier: %s", data.ClusterIdentifier.ValueString()))
+	members := auroraClusterMembers(*cluster, instances)
+	_, writerBefore := splitClusterMembers(members)
+	data.WriterBefore = types.StringValue(writerBefore)
+
+	if pending := auroraClusterPendingReboot(*cluster, instances); data.OnlyIfPendingReboot.ValueBool() && !pending.required() {
+		tflog.Info(ctx, "Skipping Aurora cluster reboot, no parameter group is pending-reboot", map[string]interface{}{
+			"cluster_identifier": clusterID,
+		})
+		data.RebootRequired = types.BoolValue(false)
+		data.PendingModifications = types.BoolValue(pending.Modifications)
+		data.WriterAfter = data.WriterBefore
 		return
 	}
 
-	cluster := describeResult.DBClusters[0]
+	tflog.Debug(ctx, "Rebooting Aurora cluster", map[string]interface{}{
+		"cluster_identifier": clusterID,
+		"strategy":           data.Strategy.ValueString(),
+		"writer":             writerBefore,
+	})
+
+	var expectedWriter string
+	switch data.Strategy.ValueString() {
+	case clusterRebootRolling:
+		expectedWriter = r.rollingReboot(ctx, client, data, members, timeout, diags)
+	case clusterRebootWriterOnly:
+		expectedWriter = r.writerOnlyReboot(ctx, client, data, members, timeout, diags)
+	default:
+		expectedWriter = r.parallelReboot(ctx, client, data, members, timeout, diags)
+	}
+	if diags.HasError() {
+		return
+	}
 
-	// Count total instances in the cluster
-	instanceCount := len(cluster.DBClusterMembers)
-
-	// Check if there are multiple instances (writer + reader)
-	hasReaderInstance := false
-	var readerInstanceID *string
-	for _, member := range cluster.DBClusterMembers {
-		if !*member.IsClusterWriter {
-			hasReaderInstance = true
-			readerInstanceID = member.DBInstanceIdentifier
-			break
+	// Changes that were pending the reboot are applied by now, confirm it
+	cluster, err = describeAuroraCluster(ctx, client, clusterID)
+	if err != nil {
+		diags.AddError("Error reading Aurora cluster", fmt.Sprintf("Could not read Aurora cluster: %s", err))
+		return
+	}
+	var pending pendingReboot
+	writerAfter := ""
+	if cluster != nil {
+		pending, err = auroraPendingReboot(ctx, client, *cluster)
+		if err != nil {
+			diags.AddError("Error reading Aurora cluster instances", fmt.Sprintf("Could not read Aurora cluster instances: %s", err))
+			return
 		}
+		_, writerAfter = splitClusterMembers(auroraClusterMembers(*cluster, nil))
+	}
+
+	if expectedWriter != "" && writerAfter != expectedWriter {
+		diags.AddError("Unexpected Aurora cluster writer", fmt.Sprintf("Aurora cluster %s failed over to %s, but its writer is now %q", clusterID, expectedWriter, writerAfter))
+		return
 	}
 
+	data.RebootRequired = types.BoolValue(pending.required())
+	data.PendingModifications = types.BoolValue(pending.Modifications)
+	data.WriterAfter = types.StringValue(writerAfter)
+
+	// Set computed values
+	currentTime := time.Now().Format(time.RFC3339)
+	data.LastRebootTime = types.StringValue(currentTime)
+}
+
+// parallelReboot fails over the cluster when force_failover is set, otherwise it reboots every member at once.
+// It returns the instance the cluster failed over to, if any.
+func (r *AuroraRebootResource) parallelReboot(ctx context.Context, client *rds.Client, data *AuroraRebootResourceModel, members []clusterMember, timeout time.Duration, diags *diag.Diagnostics) string {
+	clusterID := data.ClusterIdentifier.ValueString()
+
+	// Count total instances in the cluster
+	instanceCount := len(members)
+	readers, _ := splitClusterMembers(members)
+	target := ""
+
 	// Only use failover if:
 	// 1. There are at least 2 instances in the cluster
 	// 2. There's a reader instance available
 	// 3. force_failover is explicitly set to true
-	if instanceCount >= 2 && hasReaderInstance && !data.ForceFailover.IsNull() && data.ForceFailover.ValueBool() {
-		// Prepare failover input
-		input := &rds.FailoverDBClusterInput{
-			DBClusterIdentifier:        aws.String(data.ClusterIdentifier.ValueString()),
-			TargetDBInstanceIdentifier: readerInstanceID,
+	if instanceCount >= 2 && len(readers) > 0 && data.ForceFailover.ValueBool() {
+		var err error
+		target, err = failoverTarget(members, data.FailoverTargetInstance.ValueString())
+		if err != nil {
+			diags.AddAttributeError(path.Root("failover_target_instance"), "Invalid failover target", err.Error())
+			return ""
 		}
 
-		tflog.Debug(ctx, "Failing over Aurora cluster", map[string]interface{}{
-			"cluster_identifier": data.ClusterIdentifier.ValueString(),
-			"target_instance":    *readerInstanceID,
-			"instance_count":     instanceCount,
-		})
-
-		// Failover the Aurora cluster
-		_, err = client.FailoverDBCluster(ctx, input)
-		if err != nil {
-			resp.Diagnostics.AddError("Error failing over Aurora cluster", fmt.Sprintf("Could not failover Aurora cluster: %s", err))
-			return
+		if err := r.failover(ctx, client, clusterID, target, timeout); err != nil {
+			diags.AddError("Error failing over Aurora cluster", fmt.Sprintf("Could not failover Aurora cluster: %s", err))
+			return ""
 		}
 	} else {
 		// Reboot each instance in the cluster individually
 		tflog.Debug(ctx, "Rebooting Aurora cluster instances individually", map[string]interface{}{
-			"cluster_identifier": data.ClusterIdentifier.ValueString(),
+			"cluster_identifier": clusterID,
 			"instance_count":     instanceCount,
 			"reason":             "Single instance cluster or force_failover not enabled",
 		})
 
-		for _, member := range cluster.DBClusterMembers {
+		for _, member := range members {
 			rebootInput := &rds.RebootDBInstanceInput{
-				DBInstanceIdentifier: member.DBInstanceIdentifier,
+				DBInstanceIdentifier: aws.String(member.ID),
 			}
 
 			// Don't set ForceFailover for single-instance clusters
@@ -368,13 +434,13 @@ func (r *AuroraRebootResource) Update(ctx context.Context, req resource.UpdateRe
 			}
 
 			tflog.Debug(ctx, "Rebooting instance", map[string]interface{}{
-				"instance_identifier": *member.DBInstanceIdentifier,
+				"instance_identifier": member.ID,
 			})
 
-			_, err = client.RebootDBInstance(ctx, rebootInput)
+			_, err := client.RebootDBInstance(ctx, rebootInput)
 			if err != nil {
-				resp.Diagnostics.AddError("Error rebooting Aurora instance", fmt.Sprintf("Could not reboot Aurora instance %s: %s", *member.DBInstanceIdentifier, err))
-				return
+				diags.AddError("Error rebooting Aurora instance", fmt.Sprintf("Could not reboot Aurora instance %s: %s", member.ID, err))
+				return ""
 			}
 		}
 	}
@@ -382,22 +448,121 @@ func (r *AuroraRebootResource) Update(ctx context.Context, req resource.UpdateRe
 	// Wait for the cluster to become available again
 	waiter := rds.NewDBClusterAvailableWaiter(client)
 	waitInput := &rds.DescribeDBClustersInput{
-		DBClusterIdentifier: aws.String(data.ClusterIdentifier.ValueString()),
+		DBClusterIdentifier: aws.String(clusterID),
 	}
 
-	tflog.Debug(ctx, "Waiting for Aurora cluster to become available")
-	err = waiter.Wait(ctx, waitInput, 30*time.Minute)
+	tflog.Info(ctx, "Waiting for Aurora cluster to become available")
+	err := waiter.Wait(ctx, waitInput, timeout)
 	if err != nil {
-		resp.Diagnostics.AddError("Error waiting for Aurora cluster to become available", fmt.Sprintf("Could not confirm Aurora cluster availability: %s", err))
-		return
+		diags.AddError("Error waiting for Aurora cluster to become available", fmt.Sprintf("Could not confirm Aurora cluster availability: %s", err))
+		return ""
 	}
+	return target
+}
 
-	// Set computed values
-	currentTime := time.Now().Format(time.RFC3339)
-	data.LastRebootTime = types.StringValue(currentTime)
+// rollingReboot reboots the readers one at a time, then fails over to a reader and reboots the old writer,
+// so that the cluster keeps a writer and all but one reader available throughout. It returns the instance
+// the cluster failed over to, if any.
+func (r *AuroraRebootResource) rollingReboot(ctx context.Context, client *rds.Client, data *AuroraRebootResourceModel, members []clusterMember, timeout time.Duration, diags *diag.Diagnostics) string {
+	clusterID := data.ClusterIdentifier.ValueString()
+	readers, writer := splitClusterMembers(members)
+
+	// Check the failover target before taking any instance down
+	target := ""
+	if writer != "" && len(readers) > 0 {
+		var err error
+		target, err = failoverTarget(members, data.FailoverTargetInstance.ValueString())
+		if err != nil {
+			diags.AddAttributeError(path.Root("failover_target_instance"), "Invalid failover target", err.Error())
+			return ""
+		}
+	}
 
-	// Save updated data into Terraform state
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	for _, reader := range readers {
+		if err := rebootRDSInstance(ctx, client, reader, false, timeout); err != nil {
+			diags.AddError("Error rebooting Aurora instance", fmt.Sprintf("Could not reboot Aurora instance %s: %s", reader, err))
+			return ""
+		}
+	}
+
+	if writer == "" {
+		return ""
+	}
+
+	if target != "" {
+		if err := r.failover(ctx, client, clusterID, target, timeout); err != nil {
+			diags.AddError("Error failing over Aurora cluster", fmt.Sprintf("Could not failover Aurora cluster: %s", err))
+			return ""
+		}
+	}
+
+	// The old writer is a reader by now unless the cluster has a single instance
+	if err := rebootRDSInstance(ctx, client, writer, false, timeout); err != nil {
+		diags.AddError("Error rebooting Aurora instance", fmt.Sprintf("Could not reboot Aurora instance %s: %s", writer, err))
+		return ""
+	}
+	return target
+}
+
+// writerOnlyReboot reboots the writer and leaves the readers alone. With force_failover the cluster first
+// fails over to a reader, so the old writer is rebooted as a reader. It returns the instance the cluster
+// failed over to, if any.
+func (r *AuroraRebootResource) writerOnlyReboot(ctx context.Context, client *rds.Client, data *AuroraRebootResourceModel, members []clusterMember, timeout time.Duration, diags *diag.Diagnostics) string {
+	clusterID := data.ClusterIdentifier.ValueString()
+	readers, writer := splitClusterMembers(members)
+	if writer == "" {
+		diags.AddError("Aurora cluster has no writer", fmt.Sprintf("Aurora cluster %s has no writer instance to reboot", clusterID))
+		return ""
+	}
+
+	target := ""
+	if len(readers) > 0 && data.ForceFailover.ValueBool() {
+		var err error
+		target, err = failoverTarget(members, data.FailoverTargetInstance.ValueString())
+		if err != nil {
+			diags.AddAttributeError(path.Root("failover_target_instance"), "Invalid failover target", err.Error())
+			return ""
+		}
+
+		if err := r.failover(ctx, client, clusterID, target, timeout); err != nil {
+			diags.AddError("Error failing over Aurora cluster", fmt.Sprintf("Could not failover Aurora cluster: %s", err))
+			return ""
+		}
+	}
+
+	// Aurora instances are not Multi-AZ instances, so the reboot itself never fails over
+	if err := rebootRDSInstance(ctx, client, writer, false, timeout); err != nil {
+		diags.AddError("Error rebooting Aurora instance", fmt.Sprintf("Could not reboot Aurora instance %s: %s", writer, err))
+		return ""
+	}
+	return target
+}
+
+// failover promotes target to writer and waits until the cluster reports it as the writer
+func (r *AuroraRebootResource) failover(ctx context.Context, client *rds.Client, clusterID, target string, timeout time.Duration) error {
+	tflog.Debug(ctx, "Failing over Aurora cluster", map[string]interface{}{
+		"cluster_identifier": clusterID,
+		"target_instance":    target,
+	})
+
+	_, err := client.FailoverDBCluster(ctx, &rds.FailoverDBClusterInput{
+		DBClusterIdentifier:        aws.String(clusterID),
+		TargetDBInstanceIdentifier: aws.String(target),
+	})
+	if err != nil {
+		return err
+	}
+
+	return waitForClusterWriter(ctx, fmt.Sprintf("Aurora cluster %s", clusterID), target, timeout, func(ctx context.Context) ([]clusterMember, error) {
+		current, err := describeAuroraCluster(ctx, client, clusterID)
+		if err != nil {
+			return nil, err
+		}
+		if current == nil {
+			return nil, fmt.Errorf("aurora cluster %s not found", clusterID)
+		}
+		return auroraClusterMembers(*current, nil), nil
+	})
 }
 
 func (r *AuroraRebootResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -408,3 +573,30 @@ func (r *AuroraRebootResource) Delete(ctx context.Context, req resource.DeleteRe
 func (r *AuroraRebootResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	resource.ImportStatePassthroughID(ctx, path.Root("cluster_identifier"), req, resp)
 }
+
+// describeAuroraCluster returns the Aurora cluster with the given identifier, or nil when it does not exist
+func describeAuroraCluster(ctx context.Context, client *rds.Client, clusterID string) (*rdstypes.DBCluster, error) {
+	result, err := client.DescribeDBClusters(ctx, &rds.DescribeDBClustersInput{
+		DBClusterIdentifier: aws.String(clusterID),
+	})
+	var notFound *rdstypes.DBClusterNotFoundFault
+	if errors.As(err, &notFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(result.DBClusters) == 0 {
+		return nil, nil
+	}
+	return &result.DBClusters[0], nil
+}
+
+// auroraPendingReboot checks the cluster and its instances for changes waiting for a reboot
+func auroraPendingReboot(ctx context.Context, client *rds.Client, cluster rdstypes.DBCluster) (pendingReboot, error) {
+	instances, err := describeAuroraClusterInstances(ctx, client, aws.ToString(cluster.DBClusterIdentifier))
+	if err != nil {
+		return pendingReboot{}, err
+	}
+	return auroraClusterPendingReboot(cluster, instances), nil
+}
@@ -0,0 +1,196 @@
+// Copyright (c) IBM Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	docdbtypes "github.com/aws/aws-sdk-go-v2/service/docdb/types"
+	neptunetypes "github.com/aws/aws-sdk-go-v2/service/neptune/types"
+	rdstypes "github.com/aws/aws-sdk-go-v2/service/rds/types"
+)
+
+// Reboot strategies of the Aurora, Neptune and DocumentDB reboot resources
+const (
+	// clusterRebootRolling reboots the readers one at a time, fails over to a reader and reboots the old writer last
+	clusterRebootRolling = "rolling"
+	// clusterRebootParallel reboots every member at once
+	clusterRebootParallel = "parallel"
+	// clusterRebootWriterOnly reboots the writer and leaves the readers alone
+	clusterRebootWriterOnly = "writer_only"
+)
+
+// Statuses reported while waiting for a cluster member to become the writer
+const (
+	clusterMemberWriter = "writer"
+	clusterMemberReader = "reader"
+)
+
+// clusterMember is the engine independent view of an Aurora, Neptune or DocumentDB cluster member
+type clusterMember struct {
+	ID            string
+	Writer        bool
+	PromotionTier int32
+	// InstanceClass is only known when the instances of the cluster were described
+	InstanceClass string
+}
+
+// checkClusterRebootStrategy validates the strategy value
+func checkClusterRebootStrategy(strategy string) error {
+	switch strategy {
+	case clusterRebootRolling, clusterRebootParallel, clusterRebootWriterOnly:
+		return nil
+	}
+	return fmt.Errorf("strategy must be %s, %s or %s, got %q", clusterRebootRolling, clusterRebootParallel, clusterRebootWriterOnly, strategy)
+}
+
+// auroraClusterMembers converts the members of an Aurora cluster, taking the instance classes from instances
+func auroraClusterMembers(cluster rdstypes.DBCluster, instances []rdstypes.DBInstance) []clusterMember {
+	classes := make(map[string]string, len(instances))
+	for _, instance := range instances {
+		classes[aws.ToString(instance.DBInstanceIdentifier)] = aws.ToString(instance.DBInstanceClass)
+	}
+
+	members := make([]clusterMember, 0, len(cluster.DBClusterMembers))
+	for _, member := range cluster.DBClusterMembers {
+		id := aws.ToString(member.DBInstanceIdentifier)
+		members = append(members, clusterMember{
+			ID:            id,
+			Writer:        aws.ToBool(member.IsClusterWriter),
+			PromotionTier: aws.ToInt32(member.PromotionTier),
+			InstanceClass: classes[id],
+		})
+	}
+	return members
+}
+
+// neptuneClusterMembers converts the members of a Neptune cluster
+func neptuneClusterMembers(cluster neptunetypes.DBCluster) []clusterMember {
+	members := make([]clusterMember, 0, len(cluster.DBClusterMembers))
+	for _, member := range cluster.DBClusterMembers {
+		members = append(members, clusterMember{
+			ID:            aws.ToString(member.DBInstanceIdentifier),
+			Writer:        aws.ToBool(member.IsClusterWriter),
+			PromotionTier: aws.ToInt32(member.PromotionTier),
+		})
+	}
+	return members
+}
+
+// docdbClusterMembers converts the members of a DocumentDB cluster
+func docdbClusterMembers(cluster docdbtypes.DBCluster) []clusterMember {
+	members := make([]clusterMember, 0, len(cluster.DBClusterMembers))
+	for _, member := range cluster.DBClusterMembers {
+		members = append(members, clusterMember{
+			ID:            aws.ToString(member.DBInstanceIdentifier),
+			Writer:        aws.ToBool(member.IsClusterWriter),
+			PromotionTier: aws.ToInt32(member.PromotionTier),
+		})
+	}
+	return members
+}
+
+// splitClusterMembers returns the readers in cluster order and the writer, which is empty when
+// the cluster has no writer, for example while a failover is in progress
+func splitClusterMembers(members []clusterMember) (readers []string, writer string) {
+	for _, member := range members {
+		if member.Writer {
+			writer = member.ID
+			continue
+		}
+		readers = append(readers, member.ID)
+	}
+	return readers, writer
+}
+
+// failoverTarget returns the reader to promote when the cluster fails over. A configured target must be a
+// reader of the cluster. Otherwise the reader with the lowest promotion tier is chosen, ties are broken by
+// the largest instance class and then by the cluster order.
+func failoverTarget(members []clusterMember, configured string) (string, error) {
+	if configured != "" {
+		for _, member := range members {
+			if member.ID != configured {
+				continue
+			}
+			if member.Writer {
+				return "", fmt.Errorf("instance %s is already the writer of the cluster", configured)
+			}
+			return configured, nil
+		}
+		return "", fmt.Errorf("instance %s is not a member of the cluster", configured)
+	}
+
+	var best *clusterMember
+	for i := range members {
+		member := &members[i]
+		if member.Writer {
+			continue
+		}
+		if best == nil || member.PromotionTier < best.PromotionTier ||
+			(member.PromotionTier == best.PromotionTier && instanceClassSize(member.InstanceClass) > instanceClassSize(best.InstanceClass)) {
+			best = member
+		}
+	}
+	if best == nil {
+		return "", errors.New("the cluster has no reader to fail over to")
+	}
+	return best.ID, nil
+}
+
+// instanceSizes ranks the named instance sizes, larger sizes such as 4xlarge are multiples of xlarge
+var instanceSizes = map[string]int{
+	"nano":   1,
+	"micro":  2,
+	"small":  4,
+	"medium": 8,
+	"large":  16,
+	"xlarge": 32,
+}
+
+// instanceClassSize ranks an instance class such as db.r6g.2xlarge by its size. Unknown sizes, such as
+// db.serverless, rank lowest and metal ranks highest.
+func instanceClassSize(class string) int {
+	size := class[strings.LastIndexByte(class, '.')+1:]
+	if rank, ok := instanceSizes[size]; ok {
+		return rank
+	}
+	if size == "metal" || strings.HasPrefix(size, "metal-") {
+		return math.MaxInt
+	}
+	if multiplier, ok := strings.CutSuffix(size, "xlarge"); ok {
+		if n, err := strconv.Atoi(multiplier); err == nil {
+			return n * instanceSizes["xlarge"]
+		}
+	}
+	return 0
+}
+
+// waitForClusterWriter polls the cluster members until instanceID is reported as the writer
+func waitForClusterWriter(ctx context.Context, cluster, instanceID string, timeout time.Duration, members func(ctx context.Context) ([]clusterMember, error)) error {
+	poller := &statusPoller{
+		Resource: fmt.Sprintf("instance %s to become the writer of %s", instanceID, cluster),
+		Target:   []string{clusterMemberWriter},
+		Timeout:  timeout,
+		Refresh: func(ctx context.Context) (string, error) {
+			current, err := members(ctx)
+			if err != nil {
+				return "", err
+			}
+			if _, writer := splitClusterMembers(current); writer == instanceID {
+				return clusterMemberWriter, nil
+			}
+			return clusterMemberReader, nil
+		},
+	}
+
+	_, err := poller.Wait(ctx)
+	return err
+}
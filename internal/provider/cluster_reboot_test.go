@@ -0,0 +1,95 @@
+// Copyright (c) IBM Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	rdstypes "github.com/aws/aws-sdk-go-v2/service/rds/types"
+)
+
+func TestCheckClusterRebootStrategy(t *testing.T) {
+	for _, strategy := range []string{clusterRebootRolling, clusterRebootParallel, clusterRebootWriterOnly} {
+		if err := checkClusterRebootStrategy(strategy); err != nil {
+			t.Errorf("unexpected error for %s: %s", strategy, err)
+		}
+	}
+	if err := checkClusterRebootStrategy("readers_first"); err == nil {
+		t.Error("expected an error for an unknown strategy")
+	}
+}
+
+func TestSplitClusterMembers(t *testing.T) {
+	cluster := rdstypes.DBCluster{
+		DBClusterMembers: []rdstypes.DBClusterMember{
+			{DBInstanceIdentifier: aws.String("reader-1")},
+			{DBInstanceIdentifier: aws.String("writer"), IsClusterWriter: aws.Bool(true)},
+			{DBInstanceIdentifier: aws.String("reader-2"), IsClusterWriter: aws.Bool(false)},
+		},
+	}
+
+	readers, writer := splitClusterMembers(auroraClusterMembers(cluster, nil))
+	if writer != "writer" {
+		t.Errorf("expected writer, got %q", writer)
+	}
+	if want := []string{"reader-1", "reader-2"}; !slices.Equal(readers, want) {
+		t.Errorf("expected readers %v, got %v", want, readers)
+	}
+}
+
+func TestWaitForClusterWriter(t *testing.T) {
+	members := func(writer string) func(ctx context.Context) ([]clusterMember, error) {
+		return func(ctx context.Context) ([]clusterMember, error) {
+			return []clusterMember{{ID: "a", Writer: writer == "a"}, {ID: "b", Writer: writer == "b"}}, nil
+		}
+	}
+
+	if err := waitForClusterWriter(context.Background(), "test cluster", "b", time.Second, members("b")); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	err := waitForClusterWriter(context.Background(), "test cluster", "b", 10*time.Millisecond, members("a"))
+	if err == nil || !strings.Contains(err.Error(), "timed out") {
+		t.Errorf("expected a timeout, got %v", err)
+	}
+}
+
+func TestFailoverTarget(t *testing.T) {
+	members := []clusterMember{
+		{ID: "writer", Writer: true, PromotionTier: 0, InstanceClass: "db.r6g.8xlarge"},
+		{ID: "small-tier-1", PromotionTier: 1, InstanceClass: "db.r6g.large"},
+		{ID: "large-tier-1", PromotionTier: 1, InstanceClass: "db.r6g.2xlarge"},
+		{ID: "large-tier-2", PromotionTier: 2, InstanceClass: "db.r6g.16xlarge"},
+	}
+
+	if got, err := failoverTarget(members, ""); err != nil || got != "large-tier-1" {
+		t.Errorf("expected large-tier-1, got %q (%v)", got, err)
+	}
+	if got, err := failoverTarget(members, "large-tier-2"); err != nil || got != "large-tier-2" {
+		t.Errorf("expected the configured target, got %q (%v)", got, err)
+	}
+	if _, err := failoverTarget(members, "writer"); err == nil {
+		t.Error("expected an error when the configured target is the writer")
+	}
+	if _, err := failoverTarget(members, "missing"); err == nil {
+		t.Error("expected an error when the configured target is not a member")
+	}
+	if _, err := failoverTarget(members[:1], ""); err == nil {
+		t.Error("expected an error for a cluster without readers")
+	}
+}
+
+func TestInstanceClassSize(t *testing.T) {
+	ordered := []string{"db.serverless", "db.t4g.medium", "db.r6g.large", "db.r6g.xlarge", "db.r6g.2xlarge", "db.r6g.12xlarge", "db.r6i.metal"}
+	for i := 1; i < len(ordered); i++ {
+		if instanceClassSize(ordered[i-1]) >= instanceClassSize(ordered[i]) {
+			t.Errorf("expected %s to rank below %s", ordered[i-1], ordered[i])
+		}
+	}
+}
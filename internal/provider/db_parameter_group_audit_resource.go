@@ -0,0 +1,352 @@
+// Copyright (c) IBM Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ resource.Resource = &DBParameterGroupAuditResource{}
+
+func NewDBParameterGroupAuditResource() resource.Resource {
+	return &DBParameterGroupAuditResource{}
+}
+
+// DBParameterGroupAuditResource defines the resource implementation.
+type DBParameterGroupAuditResource struct {
+	providerData *providerData
+}
+
+// DBParameterGroupAuditResourceModel describes the resource data model.
+type DBParameterGroupAuditResourceModel struct {
+	GroupType          types.String `tfsdk:"group_type"`
+	Identifier         types.String `tfsdk:"identifier"`
+	Region             types.String `tfsdk:"region"`
+	TargetGroupName    types.String `tfsdk:"target_group_name"`
+	Parameters         types.Map    `tfsdk:"parameters"`
+	SourceGroupName    types.String `tfsdk:"source_group_name"`
+	ParameterGroupName types.String `tfsdk:"parameter_group_name"`
+	Copied             types.Bool   `tfsdk:"copied"`
+	LastModifiedTime   types.String `tfsdk:"last_modified_time"`
+	ID                 types.String `tfsdk:"id"`
+}
+
+func (r *DBParameterGroupAuditResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_db_parameter_group_audit"
+}
+
+func (r *DBParameterGroupAuditResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Resource for setting audit parameters in the parameter group of an RDS instance, an Aurora cluster, a Neptune cluster or a DocumentDB cluster. " +
+			"When the attached group is a `default.*` group it is copied first, pass `parameter_group_name` to the matching modify resource to attach the copy. " +
+			"The parameter group is kept on destroy because it may still be attached",
+
+		Attributes: map[string]schema.Attribute{
+			"group_type": schema.StringAttribute{
+				MarkdownDescription: "Kind of parameter group: `rds_instance` for the DB parameter group of an RDS instance, `rds_cluster` for the DB cluster parameter group of an Aurora cluster, " +
+					"`neptune_cluster` or `docdb_cluster`",
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"identifier": schema.StringAttribute{
+				MarkdownDescription: "The identifier of the instance or cluster whose parameter group is configured",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"region": schema.StringAttribute{
+				MarkdownDescription: "AWS region where the instance or cluster is located",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"target_group_name": schema.StringAttribute{
+				MarkdownDescription: "Name of the copy created when the attached group is a `default.*` group. Defaults to `<identifier>-audit`",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"parameters": schema.MapAttribute{
+				MarkdownDescription: "Parameter values to set, keyed by parameter name. Dynamic parameters are applied immediately, static parameters wait for the next reboot",
+				ElementType:         types.StringType,
+				Required:            true,
+			},
+			"source_group_name": schema.StringAttribute{
+				MarkdownDescription: "Parameter group attached to the instance or cluster when the resource was created",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"parameter_group_name": schema.StringAttribute{
+				MarkdownDescription: "Parameter group holding the parameters, either the copy or the attached custom group",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"copied": schema.BoolAttribute{
+				MarkdownDescription: "Whether the parameter group was copied from a `default.*` group by this resource",
+				Computed:            true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"last_modified_time": schema.StringAttribute{
+				MarkdownDescription: "Timestamp of the last modification operation",
+				Computed:            true,
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Identifier of the resource",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *DBParameterGroupAuditResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	tflog.Info(ctx, "Configuring DB parameter group audit resource")
+
+	// If provider is not configured, return
+	if req.ProviderData == nil {
+		return
+	}
+
+	// Keep the provider data, RDS, Neptune and DocumentDB clients are built per region on first use
+	pd := providerDataFrom(req.ProviderData, &resp.Diagnostics)
+	if pd == nil {
+		return
+	}
+
+	r.providerData = pd
+}
+
+func (r *DBParameterGroupAuditResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data DBParameterGroupAuditResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !r.providerData.configured(&resp.Diagnostics) {
+		return
+	}
+
+	if err := checkParameterGroupType(data.GroupType.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("group_type"), "Invalid parameter group type", err.Error())
+		return
+	}
+
+	groups := r.providerData.parameterGroups(data.GroupType.ValueString(), data.Region.ValueString())
+	identifier := data.Identifier.ValueString()
+
+	source, err := groups.attachedGroup(ctx, identifier)
+	if errors.Is(err, errParameterGroupNotFound) {
+		resp.Diagnostics.AddError("Instance or cluster not found", fmt.Sprintf("No %s parameter group found for %s", data.GroupType.ValueString(), identifier))
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading attached parameter group", fmt.Sprintf("Could not read the parameter group of %s: %s", identifier, err))
+		return
+	}
+
+	data.SourceGroupName = types.StringValue(source)
+	data.ParameterGroupName = types.StringValue(source)
+	data.Copied = types.BoolValue(false)
+
+	// Default groups cannot be modified, work on a copy instead
+	if isDefaultParameterGroup(source) {
+		target := data.TargetGroupName.ValueString()
+		if target == "" {
+			target = identifier + "-audit"
+		}
+
+		tflog.Info(ctx, "Copying default parameter group", map[string]interface{}{
+			"source_group_name": source,
+			"target_group_name": target,
+		})
+
+		existed, err := groups.copyGroup(ctx, source, target, fmt.Sprintf("Copy of %s with audit parameters for %s", source, identifier))
+		if err != nil {
+			resp.Diagnostics.AddError("Error copying parameter group", fmt.Sprintf("Could not copy parameter group %s to %s: %s", source, target, err))
+			return
+		}
+		if existed {
+			tflog.Warn(ctx, "Parameter group already exists, reusing it", map[string]interface{}{
+				"target_group_name": target,
+			})
+		}
+
+		data.ParameterGroupName = types.StringValue(target)
+		data.Copied = types.BoolValue(!existed)
+	}
+
+	r.apply(ctx, groups, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = types.StringValue(data.ParameterGroupName.ValueString())
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *DBParameterGroupAuditResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data DBParameterGroupAuditResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !r.providerData.configured(&resp.Diagnostics) {
+		return
+	}
+
+	var wanted map[string]string
+	resp.Diagnostics.Append(data.Parameters.ElementsAs(ctx, &wanted, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	groups := r.providerData.parameterGroups(data.GroupType.ValueString(), data.Region.ValueString())
+	names := make([]string, 0, len(wanted))
+	for name := range wanted {
+		names = append(names, name)
+	}
+
+	current, err := groups.values(ctx, data.ParameterGroupName.ValueString(), names)
+	if errors.Is(err, errParameterGroupNotFound) {
+		tflog.Warn(ctx, "Parameter group not found, removing parameter group audit resource from state", map[string]interface{}{
+			"parameter_group_name": data.ParameterGroupName.ValueString(),
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading parameter group", fmt.Sprintf("Could not read parameter group %s: %s", data.ParameterGroupName.ValueString(), err))
+		return
+	}
+
+	// Refresh the values so that changes made outside of Terraform show up as a diff, unset parameters are dropped
+	actual := make(map[string]string, len(current))
+	for _, p := range current {
+		if !p.Value.IsNull() {
+			actual[p.Name.ValueString()] = p.Value.ValueString()
+		}
+	}
+	parameters, diags := types.MapValueFrom(ctx, types.StringType, actual)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Parameters = parameters
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *DBParameterGroupAuditResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state DBParameterGroupAuditResourceModel
+
+	// Read Terraform plan and prior state data into the models
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !r.providerData.configured(&resp.Diagnostics) {
+		return
+	}
+
+	// The group is chosen on create, only the parameters change afterwards
+	data.SourceGroupName = state.SourceGroupName
+	data.ParameterGroupName = state.ParameterGroupName
+	data.Copied = state.Copied
+
+	groups := r.providerData.parameterGroups(data.GroupType.ValueString(), data.Region.ValueString())
+	r.apply(ctx, groups, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// apply sets the wanted parameters in the parameter group, dynamic parameters are applied immediately
+// and static parameters wait for the next reboot
+func (r *DBParameterGroupAuditResource) apply(ctx context.Context, groups parameterGroupClient, data *DBParameterGroupAuditResourceModel, diags *diag.Diagnostics) {
+	group := data.ParameterGroupName.ValueString()
+
+	var wanted map[string]string
+	diags.Append(data.Parameters.ElementsAs(ctx, &wanted, false)...)
+	if diags.HasError() {
+		return
+	}
+
+	names := make([]string, 0, len(wanted))
+	for name := range wanted {
+		names = append(names, name)
+	}
+
+	current, err := groups.values(ctx, group, names)
+	if err != nil {
+		diags.AddError("Error reading parameter group", fmt.Sprintf("Could not read parameter group %s: %s", group, err))
+		return
+	}
+
+	changes, err := parameterChanges(wanted, current)
+	if err != nil {
+		diags.AddAttributeError(path.Root("parameters"), "Invalid parameter", fmt.Sprintf("Could not set parameters in %s: %s", group, err))
+		return
+	}
+
+	tflog.Debug(ctx, "Modifying parameter group", map[string]interface{}{
+		"parameter_group_name": group,
+		"changes":              len(changes),
+	})
+
+	if err := applyParameterChanges(ctx, groups, group, changes); err != nil {
+		diags.AddError("Error modifying parameter group", fmt.Sprintf("Could not modify parameter group %s: %s", group, err))
+		return
+	}
+
+	// Set computed values
+	currentTime := time.Now().Format(time.RFC3339)
+	data.LastModifiedTime = types.StringValue(currentTime)
+}
+
+func (r *DBParameterGroupAuditResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// No action needed on delete - the parameter group may still be attached to the instance or cluster
+	// The resource will be removed from state
+}
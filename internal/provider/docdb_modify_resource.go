@@ -0,0 +1,362 @@
+// Copyright (c) IBM Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/docdb"
+	"github.com/aws/aws-sdk-go-v2/service/docdb/types"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	frameworktypes "github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ resource.Resource = &DocDBModifyResource{}
+var _ resource.ResourceWithImportState = &DocDBModifyResource{}
+
+func NewDocDBModifyResource() resource.Resource {
+	return &DocDBModifyResource{}
+}
+
+// DocDBModifyResource defines the resource implementation.
+type DocDBModifyResource struct {
+	providerData *providerData
+}
+
+// DocDBModifyResourceModel describes the resource data model.
+type DocDBModifyResourceModel struct {
+	ClusterIdentifier         frameworktypes.String `tfsdk:"cluster_identifier"`
+	Region                    frameworktypes.String `tfsdk:"region"`
+	ClusterParameterGroupName frameworktypes.String `tfsdk:"cluster_parameter_group_name"`
+	CloudWatchLogsExports     frameworktypes.List   `tfsdk:"cloudwatch_logs_exports"`
+	ApplyImmediately          frameworktypes.Bool   `tfsdk:"apply_immediately"`
+	LastModifiedTime          frameworktypes.String `tfsdk:"last_modified_time"`
+	ID                        frameworktypes.String `tfsdk:"id"`
+	Timeouts                  timeouts.Value        `tfsdk:"timeouts"`
+}
+
+func (r *DocDBModifyResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_docdb_modify"
+}
+
+func (r *DocDBModifyResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Resource for modifying an AWS DocumentDB cluster configuration",
+
+		Attributes: map[string]schema.Attribute{
+			"cluster_identifier": schema.StringAttribute{
+				MarkdownDescription: "The identifier of the DocumentDB cluster to modify",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"region": schema.StringAttribute{
+				MarkdownDescription: "AWS region where the DocumentDB cluster is located",
+				Optional:            true,
+			},
+			"cluster_parameter_group_name": schema.StringAttribute{
+				MarkdownDescription: "The name of the DB cluster parameter group to apply",
+				Optional:            true,
+			},
+			"cloudwatch_logs_exports": schema.ListAttribute{
+				MarkdownDescription: "List of log types to enable for exporting to CloudWatch Logs (e.g., 'audit', 'profiler')",
+				ElementType:         frameworktypes.StringType,
+				Optional:            true,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.RequiresReplace(),
+				},
+			},
+			"apply_immediately": schema.BoolAttribute{
+				MarkdownDescription: "Whether to apply changes immediately or during the next maintenance window",
+				Optional:            true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"last_modified_time": schema.StringAttribute{
+				MarkdownDescription: "Timestamp of the last modification operation",
+				Computed:            true,
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Identifier of the resource",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Update: true,
+			}),
+		},
+	}
+}
+
+func (r *DocDBModifyResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	tflog.Info(ctx, "Configuring DocumentDB modify resource")
+
+	// If provider is not configured, return
+	if req.ProviderData == nil {
+		return
+	}
+
+	// Keep the provider data, DocumentDB clients are built per region on first use
+	pd := providerDataFrom(req.ProviderData, &resp.Diagnostics)
+	if pd == nil {
+		return
+	}
+
+	r.providerData = pd
+}
+
+func (r *DocDBModifyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data DocDBModifyResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !r.providerData.configured(&resp.Diagnostics) {
+		return
+	}
+
+	timeout, diags := data.Timeouts.Create(ctx, defaultWaitTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	// Get the cached client for the requested region
+	client := r.providerData.docdbClient(data.Region.ValueString())
+
+	// Prepare modify input
+	input := &docdb.ModifyDBClusterInput{
+		DBClusterIdentifier: aws.String(data.ClusterIdentifier.ValueString()),
+	}
+
+	// Set cluster parameter group name if specified
+	if !data.ClusterParameterGroupName.IsNull() {
+		input.DBClusterParameterGroupName = aws.String(data.ClusterParameterGroupName.ValueString())
+	}
+
+	// Set CloudWatch logs exports if specified
+	if !data.CloudWatchLogsExports.IsNull() {
+		var logsExports []string
+		resp.Diagnostics.Append(data.CloudWatchLogsExports.ElementsAs(ctx, &logsExports, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		input.CloudwatchLogsExportConfiguration = &types.CloudwatchLogsExportConfiguration{
+			EnableLogTypes: logsExports,
+		}
+	}
+
+	// Set apply immediately if specified
+	if !data.ApplyImmediately.IsNull() {
+		input.ApplyImmediately = aws.Bool(data.ApplyImmediately.ValueBool())
+	}
+
+	tflog.Debug(ctx, "Modifying DocumentDB cluster", map[string]interface{}{
+		"cluster_identifier":           data.ClusterIdentifier.ValueString(),
+		"cluster_parameter_group_name": data.ClusterParameterGroupName.ValueString(),
+		"apply_immediately":            data.ApplyImmediately.ValueBool(),
+	})
+
+	// Modify the DocumentDB cluster
+	_, err := client.ModifyDBCluster(ctx, input)
+	if err != nil {
+		resp.Diagnostics.AddError("Error modifying DocumentDB cluster", fmt.Sprintf("Could not modify DocumentDB cluster: %s", err))
+		return
+	}
+
+	// Wait for the cluster to become available again
+	tflog.Info(ctx, "Waiting for DocumentDB cluster to become available after modification")
+	if err := waitForDocDBClusterAvailable(ctx, client, data.ClusterIdentifier.ValueString(), timeout); err != nil {
+		resp.Diagnostics.AddError("Error waiting for DocumentDB cluster to become available", fmt.Sprintf("Could not confirm DocumentDB cluster availability: %s", err))
+		return
+	}
+
+	// Set computed values
+	currentTime := time.Now().Format(time.RFC3339)
+	data.LastModifiedTime = frameworktypes.StringValue(currentTime)
+	data.ID = frameworktypes.StringValue(data.ClusterIdentifier.ValueString())
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *DocDBModifyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data DocDBModifyResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !r.providerData.configured(&resp.Diagnostics) {
+		return
+	}
+
+	// Get the cached client for the requested region
+	client := r.providerData.docdbClient(data.Region.ValueString())
+
+	// Check if the DocumentDB cluster exists
+	input := &docdb.DescribeDBClustersInput{
+		DBClusterIdentifier: aws.String(data.ClusterIdentifier.ValueString()),
+	}
+
+	_, err := client.DescribeDBClusters(ctx, input)
+	var notFound *types.DBClusterNotFoundFault
+	if errors.As(err, &notFound) {
+		tflog.Warn(ctx, "DocumentDB cluster not found, removing modify resource from state", map[string]interface{}{
+			"cluster_identifier": data.ClusterIdentifier.ValueString(),
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading DocumentDB cluster", fmt.Sprintf("Could not read DocumentDB cluster: %s", err))
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *DocDBModifyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data DocDBModifyResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !r.providerData.configured(&resp.Diagnostics) {
+		return
+	}
+
+	timeout, diags := data.Timeouts.Update(ctx, defaultWaitTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	// Get the cached client for the requested region
+	client := r.providerData.docdbClient(data.Region.ValueString())
+
+	// Prepare modify input
+	input := &docdb.ModifyDBClusterInput{
+		DBClusterIdentifier: aws.String(data.ClusterIdentifier.ValueString()),
+	}
+
+	// Set cluster parameter group name if specified
+	if !data.ClusterParameterGroupName.IsNull() {
+		input.DBClusterParameterGroupName = aws.String(data.ClusterParameterGroupName.ValueString())
+	}
+
+	// Set CloudWatch logs exports if specified
+	if !data.CloudWatchLogsExports.IsNull() {
+		var logsExports []string
+		resp.Diagnostics.Append(data.CloudWatchLogsExports.ElementsAs(ctx, &logsExports, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		input.CloudwatchLogsExportConfiguration = &types.CloudwatchLogsExportConfiguration{
+			EnableLogTypes: logsExports,
+		}
+	}
+
+	// Set apply immediately if specified
+	if !data.ApplyImmediately.IsNull() {
+		input.ApplyImmediately = aws.Bool(data.ApplyImmediately.ValueBool())
+	}
+
+	tflog.Debug(ctx, "Modifying DocumentDB cluster", map[string]interface{}{
+		"cluster_identifier":           data.ClusterIdentifier.ValueString(),
+		"cluster_parameter_group_name": data.ClusterParameterGroupName.ValueString(),
+		"apply_immediately":            data.ApplyImmediately.ValueBool(),
+	})
+
+	// Modify the DocumentDB cluster
+	_, err := client.ModifyDBCluster(ctx, input)
+	if err != nil {
+		resp.Diagnostics.AddError("Error modifying DocumentDB cluster", fmt.Sprintf("Could not modify DocumentDB cluster: %s", err))
+		return
+	}
+
+	// Wait for the cluster to become available again
+	tflog.Debug(ctx, "Waiting for DocumentDB cluster to become available after modification")
+	if err := waitForDocDBClusterAvailable(ctx, client, data.ClusterIdentifier.ValueString(), timeout); err != nil {
+		resp.Diagnostics.AddError("Error waiting for DocumentDB cluster to become available", fmt.Sprintf("Could not confirm DocumentDB cluster availability: %s", err))
+		return
+	}
+
+	// Set computed values
+	currentTime := time.Now().Format(time.RFC3339)
+	data.LastModifiedTime = frameworktypes.StringValue(currentTime)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *DocDBModifyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// No action needed on delete - this is a stateless operation
+	// The resource will be removed from state
+}
+
+func (r *DocDBModifyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("cluster_identifier"), req, resp)
+}
+
+// waitForDocDBClusterAvailable polls the DocumentDB cluster status until it is available
+func waitForDocDBClusterAvailable(ctx context.Context, client *docdb.Client, clusterID string, timeout time.Duration) error {
+	poller := &statusPoller{
+		Resource: fmt.Sprintf("DocumentDB cluster %s", clusterID),
+		Target:   []string{"available"},
+		Failure:  dbFailureStatuses,
+		Timeout:  timeout,
+		Refresh: func(ctx context.Context) (string, error) {
+			result, err := client.DescribeDBClusters(ctx, &docdb.DescribeDBClustersInput{
+				DBClusterIdentifier: aws.String(clusterID),
+			})
+			if err != nil {
+				return "", err
+			}
+			if len(result.DBClusters) == 0 {
+				return "", fmt.Errorf("docdb cluster %s not found", clusterID)
+			}
+			return aws.ToString(result.DBClusters[0].Status), nil
+		},
+	}
+
+	_, err := poller.Wait(ctx)
+	return err
+}
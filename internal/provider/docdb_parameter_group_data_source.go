@@ -8,7 +8,6 @@ import (
 	"fmt"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
-	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/docdb"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
@@ -26,7 +25,7 @@ func NewDocDBParameterGroupDataSource() datasource.DataSource {
 
 // DocDBParameterGroupDataSource defines the data source implementation.
 type DocDBParameterGroupDataSource struct {
-	client *docdb.Client
+	providerData *providerData
 }
 
 // DocDBParameterGroupDataSourceModel describes the data source data model.
@@ -36,6 +35,8 @@ type DocDBParameterGroupDataSourceModel struct {
 	ParameterGroup    types.String `tfsdk:"parameter_group"`
 	FamilyName        types.String `tfsdk:"family_name"`
 	Description       types.String `tfsdk:"description"`
+	Names             types.List   `tfsdk:"names"`
+	Parameters        types.List   `tfsdk:"parameters"`
 	ID                types.String `tfsdk:"id"`
 }
 
@@ -69,6 +70,8 @@ func (d *DocDBParameterGroupDataSource) Schema(ctx context.Context, req datasour
 				MarkdownDescription: "DocumentDB family name",
 				Computed:            true,
 			},
+			"names":      parameterNamesAttribute("`audit_logs`"),
+			"parameters": parametersAttribute(),
 			"id": schema.StringAttribute{
 				Computed:            true,
 				MarkdownDescription: "Identifier of the data source",
@@ -85,14 +88,13 @@ func (d *DocDBParameterGroupDataSource) Configure(ctx context.Context, req datas
 		return
 	}
 
-	// Create AWS config and DocumentDB client
-	awsCfg, err := config.LoadDefaultConfig(ctx)
-	if err != nil {
-		resp.Diagnostics.AddError("Unable to load AWS SDK config", fmt.Sprintf("Unable to load AWS SDK config: %s", err))
+	// Keep the provider data, DocumentDB clients are built per region on first use
+	pd := providerDataFrom(req.ProviderData, &resp.Diagnostics)
+	if pd == nil {
 		return
 	}
 
-	d.client = docdb.NewFromConfig(awsCfg)
+	d.providerData = pd
 }
 
 func (d *DocDBParameterGroupDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -104,21 +106,13 @@ func (d *DocDBParameterGroupDataSource) Read(ctx context.Context, req datasource
 		return
 	}
 
-	// If region is specified, update the AWS config
-	var client *docdb.Client
-	if !data.Region.IsNull() {
-		tflog.Debug(ctx, "configuring client with region")
-		awsCfg, err := config.LoadDefaultConfig(ctx, config.WithRegion(data.Region.ValueString()))
-		if err != nil {
-			resp.Diagnostics.AddError("Unable to load AWS SDK config", fmt.Sprintf("Unable to load AWS SDK config with region %s: %s", data.Region.ValueString(), err))
-			return
-		}
-		client = docdb.NewFromConfig(awsCfg)
-	} else {
-		tflog.Debug(ctx, "using default client")
-		client = d.client
+	if !d.providerData.configured(&resp.Diagnostics) {
+		return
 	}
 
+	// Get the cached client for the requested region
+	client := d.providerData.docdbClient(data.Region.ValueString())
+
 	// Get DocumentDB cluster information
 	input := &docdb.DescribeDBClustersInput{DBClusterIdentifier: aws.String(data.ClusterIdentifier.ValueString())}
 
@@ -160,5 +154,21 @@ func (d *DocDBParameterGroupDataSource) Read(ctx context.Context, req datasource
 	data.Description = types.StringValue(*pgResp.DBClusterParameterGroups[0].Description)
 	data.ID = types.StringValue(data.ClusterIdentifier.ValueString())
 
+	// Read the parameter values, restricted to names when set
+	names := parameterNames(ctx, data.Names, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	parameters, err := docdbClusterParameterValues(ctx, client, data.ParameterGroup.ValueString(), names)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to describe DB cluster parameters", fmt.Sprintf("Error describing parameters of %s: %s", data.ParameterGroup.ValueString(), err))
+		return
+	}
+	data.Parameters = parametersListValue(ctx, parameters, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -0,0 +1,468 @@
+// Copyright (c) IBM Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/docdb"
+	docdbtypes "github.com/aws/aws-sdk-go-v2/service/docdb/types"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ resource.Resource = &DocDBRebootResource{}
+var _ resource.ResourceWithImportState = &DocDBRebootResource{}
+
+func NewDocDBRebootResource() resource.Resource {
+	return &DocDBRebootResource{}
+}
+
+// DocDBRebootResource defines the resource implementation.
+type DocDBRebootResource struct {
+	providerData *providerData
+}
+
+// DocDBRebootResourceModel describes the resource data model.
+type DocDBRebootResourceModel struct {
+	ClusterIdentifier   types.String   `tfsdk:"cluster_identifier"`
+	Region              types.String   `tfsdk:"region"`
+	OnlyIfPendingReboot types.Bool     `tfsdk:"only_if_pending_reboot"`
+	Strategy            types.String   `tfsdk:"strategy"`
+	LastRebootTime      types.String   `tfsdk:"last_reboot_time"`
+	ID                  types.String   `tfsdk:"id"`
+	Timeouts            timeouts.Value `tfsdk:"timeouts"`
+}
+
+func (r *DocDBRebootResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_docdb_reboot"
+}
+
+func (r *DocDBRebootResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Resource for rebooting all instances in an AWS DocumentDB cluster",
+
+		Attributes: map[string]schema.Attribute{
+			"cluster_identifier": schema.StringAttribute{
+				MarkdownDescription: "The identifier of the DocumentDB cluster whose instances will be rebooted",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"region": schema.StringAttribute{
+				MarkdownDescription: "AWS region where the DocumentDB cluster is located",
+				Optional:            true,
+			},
+			"strategy": schema.StringAttribute{
+				MarkdownDescription: "How the cluster is rebooted: `rolling` reboots the readers one at a time and waits for each to be available, then fails over to a reader and reboots the old writer. " +
+					"`parallel` reboots every instance at once. `writer_only` reboots just the writer. Defaults to `parallel`",
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString(clusterRebootParallel),
+			},
+			"only_if_pending_reboot": schema.BoolAttribute{
+				MarkdownDescription: "When true, the instances are only rebooted when the cluster parameter group of a member is in `pending-reboot` state",
+				Optional:            true,
+			},
+			"last_reboot_time": schema.StringAttribute{
+				MarkdownDescription: "Timestamp of the last reboot operation",
+				Computed:            true,
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Identifier of the resource",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Update: true,
+			}),
+		},
+	}
+}
+
+func (r *DocDBRebootResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	tflog.Info(ctx, "Configuring DocumentDB reboot resource")
+
+	// If provider is not configured, return
+	if req.ProviderData == nil {
+		return
+	}
+
+	// Keep the provider data, DocumentDB clients are built per region on first use
+	pd := providerDataFrom(req.ProviderData, &resp.Diagnostics)
+	if pd == nil {
+		return
+	}
+
+	r.providerData = pd
+}
+
+func (r *DocDBRebootResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data DocDBRebootResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !r.providerData.configured(&resp.Diagnostics) {
+		return
+	}
+
+	if err := checkClusterRebootStrategy(data.Strategy.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("strategy"), "Invalid reboot strategy", err.Error())
+		return
+	}
+
+	timeout, diags := data.Timeouts.Create(ctx, defaultWaitTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	// Stays null when the reboot is skipped
+	data.LastRebootTime = types.StringNull()
+
+	r.reboot(ctx, &data, timeout, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = types.StringValue(data.ClusterIdentifier.ValueString())
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *DocDBRebootResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data DocDBRebootResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !r.providerData.configured(&resp.Diagnostics) {
+		return
+	}
+
+	// Get the cached client for the requested region
+	client := r.providerData.docdbClient(data.Region.ValueString())
+
+	// Check if the DocumentDB cluster exists
+	input := &docdb.DescribeDBClustersInput{
+		DBClusterIdentifier: aws.String(data.ClusterIdentifier.ValueString()),
+	}
+
+	_, err := client.DescribeDBClusters(ctx, input)
+	var notFound *docdbtypes.DBClusterNotFoundFault
+	if errors.As(err, &notFound) {
+		tflog.Warn(ctx, "DocumentDB cluster not found, removing reboot resource from state", map[string]interface{}{
+			"cluster_identifier": data.ClusterIdentifier.ValueString(),
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading DocumentDB cluster", fmt.Sprintf("Could not read DocumentDB cluster: %s", err))
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *DocDBRebootResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state DocDBRebootResourceModel
+
+	// Read Terraform plan and prior state data into the models
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !r.providerData.configured(&resp.Diagnostics) {
+		return
+	}
+
+	if err := checkClusterRebootStrategy(data.Strategy.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("strategy"), "Invalid reboot strategy", err.Error())
+		return
+	}
+
+	timeout, diags := data.Timeouts.Update(ctx, defaultWaitTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	// Kept when the reboot is skipped
+	data.LastRebootTime = state.LastRebootTime
+
+	r.reboot(ctx, &data, timeout, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// reboot reboots every instance of the cluster, waits for them to become available again and records the
+// outcome in data. With only_if_pending_reboot the reboot is skipped when no member is pending-reboot.
+func (r *DocDBRebootResource) reboot(ctx context.Context, data *DocDBRebootResourceModel, timeout time.Duration, diags *diag.Diagnostics) {
+	// Get the cached client for the requested region
+	client := r.providerData.docdbClient(data.Region.ValueString())
+
+	// Get all instances in the cluster
+	describeInput := &docdb.DescribeDBClustersInput{
+		DBClusterIdentifier: aws.String(data.ClusterIdentifier.ValueString()),
+	}
+
+	clusterOutput, err := client.DescribeDBClusters(ctx, describeInput)
+	if err != nil {
+		diags.AddError("Error describing DocumentDB cluster", fmt.Sprintf("Could not describe DocumentDB cluster: %s", err))
+		return
+	}
+
+	if len(clusterOutput.DBClusters) == 0 {
+		diags.AddError("DocumentDB cluster not found", fmt.Sprintf("DocumentDB cluster %s not found", data.ClusterIdentifier.ValueString()))
+		return
+	}
+
+	cluster := clusterOutput.DBClusters[0]
+	instances := cluster.DBClusterMembers
+
+	if len(instances) == 0 {
+		diags.AddError("No instances in cluster", fmt.Sprintf("DocumentDB cluster %s has no instances to reboot", data.ClusterIdentifier.ValueString()))
+		return
+	}
+
+	if data.OnlyIfPendingReboot.ValueBool() && !docdbClusterPendingReboot(cluster) {
+		tflog.Info(ctx, "Skipping DocumentDB cluster reboot, no cluster parameter group is pending-reboot", map[string]interface{}{
+			"cluster_identifier": data.ClusterIdentifier.ValueString(),
+		})
+		return
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Found %d instances in DocumentDB cluster %s", len(instances), data.ClusterIdentifier.ValueString()), map[string]interface{}{
+		"strategy": data.Strategy.ValueString(),
+	})
+
+	switch data.Strategy.ValueString() {
+	case clusterRebootRolling:
+		r.rollingReboot(ctx, client, data, cluster, timeout, diags)
+	case clusterRebootWriterOnly:
+		r.writerOnlyReboot(ctx, client, data, cluster, timeout, diags)
+	default:
+		r.parallelReboot(ctx, client, data, cluster, timeout, diags)
+	}
+	if diags.HasError() {
+		return
+	}
+
+	// Set computed values
+	currentTime := time.Now().Format(time.RFC3339)
+	data.LastRebootTime = types.StringValue(currentTime)
+
+	tflog.Info(ctx, fmt.Sprintf("Successfully rebooted DocumentDB cluster %s", data.ClusterIdentifier.ValueString()))
+}
+
+// parallelReboot reboots every instance of the cluster at once, then waits for all of them
+func (r *DocDBRebootResource) parallelReboot(ctx context.Context, client *docdb.Client, data *DocDBRebootResourceModel, cluster docdbtypes.DBCluster, timeout time.Duration, diags *diag.Diagnostics) {
+	instances := cluster.DBClusterMembers
+
+	// Reboot each instance in the cluster
+	for _, member := range instances {
+		instanceId := aws.ToString(member.DBInstanceIdentifier)
+
+		tflog.Debug(ctx, "Rebooting DocumentDB instance", map[string]interface{}{
+			"cluster_identifier":  data.ClusterIdentifier.ValueString(),
+			"instance_identifier": instanceId,
+		})
+
+		rebootInput := &docdb.RebootDBInstanceInput{
+			DBInstanceIdentifier: aws.String(instanceId),
+		}
+
+		_, err := client.RebootDBInstance(ctx, rebootInput)
+		if err != nil {
+			diags.AddError("Error rebooting DocumentDB instance", fmt.Sprintf("Could not reboot DocumentDB instance %s: %s", instanceId, err))
+			return
+		}
+
+		tflog.Info(ctx, fmt.Sprintf("Successfully initiated reboot for DocumentDB instance: %s", instanceId))
+	}
+
+	// Wait for all instances to become available again
+	tflog.Info(ctx, "Waiting for all DocumentDB instances to become available")
+
+	for _, member := range instances {
+		instanceId := aws.ToString(member.DBInstanceIdentifier)
+
+		tflog.Debug(ctx, fmt.Sprintf("Waiting for DocumentDB instance %s to become available", instanceId))
+		err := waitForDocDBInstanceAvailable(ctx, client, instanceId, timeout)
+		if err != nil {
+			diags.AddError("Error waiting for DocumentDB instance to become available", fmt.Sprintf("Could not confirm DocumentDB instance %s availability: %s", instanceId, err))
+			return
+		}
+
+		tflog.Info(ctx, fmt.Sprintf("DocumentDB instance %s is now available", instanceId))
+	}
+}
+
+// rollingReboot reboots the readers one at a time, then fails over to a reader and reboots the old writer,
+// so that the cluster keeps a writer and all but one reader available throughout
+func (r *DocDBRebootResource) rollingReboot(ctx context.Context, client *docdb.Client, data *DocDBRebootResourceModel, cluster docdbtypes.DBCluster, timeout time.Duration, diags *diag.Diagnostics) {
+	clusterID := data.ClusterIdentifier.ValueString()
+	members := docdbClusterMembers(cluster)
+	readers, writer := splitClusterMembers(members)
+
+	for _, reader := range readers {
+		if err := rebootDocDBInstance(ctx, client, reader, timeout); err != nil {
+			diags.AddError("Error rebooting DocumentDB instance", fmt.Sprintf("Could not reboot DocumentDB instance %s: %s", reader, err))
+			return
+		}
+	}
+
+	if writer == "" {
+		return
+	}
+
+	if len(readers) > 0 {
+		// Promote the reader with the lowest promotion tier
+		target, err := failoverTarget(members, "")
+		if err != nil {
+			diags.AddError("Error failing over DocumentDB cluster", fmt.Sprintf("Could not choose a failover target: %s", err))
+			return
+		}
+
+		tflog.Debug(ctx, "Failing over DocumentDB cluster", map[string]interface{}{
+			"cluster_identifier": clusterID,
+			"target_instance":    target,
+		})
+
+		_, err = client.FailoverDBCluster(ctx, &docdb.FailoverDBClusterInput{
+			DBClusterIdentifier:        aws.String(clusterID),
+			TargetDBInstanceIdentifier: aws.String(target),
+		})
+		if err != nil {
+			diags.AddError("Error failing over DocumentDB cluster", fmt.Sprintf("Could not failover DocumentDB cluster: %s", err))
+			return
+		}
+
+		err = waitForClusterWriter(ctx, fmt.Sprintf("DocumentDB cluster %s", clusterID), target, timeout, func(ctx context.Context) ([]clusterMember, error) {
+			result, err := client.DescribeDBClusters(ctx, &docdb.DescribeDBClustersInput{
+				DBClusterIdentifier: aws.String(clusterID),
+			})
+			if err != nil {
+				return nil, err
+			}
+			if len(result.DBClusters) == 0 {
+				return nil, fmt.Errorf("docdb cluster %s not found", clusterID)
+			}
+			return docdbClusterMembers(result.DBClusters[0]), nil
+		})
+		if err != nil {
+			diags.AddError("Error waiting for DocumentDB failover", fmt.Sprintf("Could not confirm DocumentDB cluster failover: %s", err))
+			return
+		}
+	}
+
+	// The old writer is a reader by now unless the cluster has a single instance
+	if err := rebootDocDBInstance(ctx, client, writer, timeout); err != nil {
+		diags.AddError("Error rebooting DocumentDB instance", fmt.Sprintf("Could not reboot DocumentDB instance %s: %s", writer, err))
+	}
+}
+
+// writerOnlyReboot reboots the writer and leaves the readers alone
+func (r *DocDBRebootResource) writerOnlyReboot(ctx context.Context, client *docdb.Client, data *DocDBRebootResourceModel, cluster docdbtypes.DBCluster, timeout time.Duration, diags *diag.Diagnostics) {
+	_, writer := splitClusterMembers(docdbClusterMembers(cluster))
+	if writer == "" {
+		diags.AddError("DocumentDB cluster has no writer", fmt.Sprintf("DocumentDB cluster %s has no writer instance to reboot", data.ClusterIdentifier.ValueString()))
+		return
+	}
+
+	if err := rebootDocDBInstance(ctx, client, writer, timeout); err != nil {
+		diags.AddError("Error rebooting DocumentDB instance", fmt.Sprintf("Could not reboot DocumentDB instance %s: %s", writer, err))
+	}
+}
+
+func (r *DocDBRebootResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// No action needed on delete - this is a stateless operation
+	// The resource will be removed from state
+}
+
+func (r *DocDBRebootResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("cluster_identifier"), req, resp)
+}
+
+// rebootDocDBInstance reboots a single instance and waits for it to become available again
+func rebootDocDBInstance(ctx context.Context, client *docdb.Client, instanceID string, timeout time.Duration) error {
+	tflog.Debug(ctx, "Rebooting DocumentDB instance", map[string]interface{}{
+		"instance_identifier": instanceID,
+	})
+
+	_, err := client.RebootDBInstance(ctx, &docdb.RebootDBInstanceInput{
+		DBInstanceIdentifier: aws.String(instanceID),
+	})
+	if err != nil {
+		return err
+	}
+	return waitForDocDBInstanceAvailable(ctx, client, instanceID, timeout)
+}
+
+// waitForDocDBInstanceAvailable polls the DocumentDB instance status until it is available
+func waitForDocDBInstanceAvailable(ctx context.Context, client *docdb.Client, instanceID string, timeout time.Duration) error {
+	poller := &statusPoller{
+		Resource: fmt.Sprintf("DocumentDB instance %s", instanceID),
+		Target:   []string{"available"},
+		Failure:  dbFailureStatuses,
+		Timeout:  timeout,
+		Refresh: func(ctx context.Context) (string, error) {
+			result, err := client.DescribeDBInstances(ctx, &docdb.DescribeDBInstancesInput{
+				DBInstanceIdentifier: aws.String(instanceID),
+			})
+			if err != nil {
+				return "", err
+			}
+			if len(result.DBInstances) == 0 {
+				return "", fmt.Errorf("docdb instance %s not found", instanceID)
+			}
+			return aws.ToString(result.DBInstances[0].DBInstanceStatus), nil
+		},
+	}
+
+	_, err := poller.Wait(ctx)
+	return err
+}
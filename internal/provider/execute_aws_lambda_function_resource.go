@@ -5,17 +5,28 @@ package provider
 
 import (
 	"context"
+	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
-	"github.com/aws/aws-sdk-go-v2/config"
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
 	lambdasvc "github.com/aws/aws-sdk-go-v2/service/lambda"
+	lambdatypes "github.com/aws/aws-sdk-go-v2/service/lambda/types"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	ssmtypes "github.com/aws/aws-sdk-go-v2/service/ssm/types"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
@@ -24,6 +35,35 @@ import (
 // Ensure the implementation satisfies the expected interfaces.
 var _ resource.Resource = &executeAwsLambdaFunctionResource{}
 var _ resource.ResourceWithImportState = &executeAwsLambdaFunctionResource{}
+var _ resource.ResourceWithConfigure = &executeAwsLambdaFunctionResource{}
+
+// Lifecycle scopes of the Lambda invocation
+const (
+	lambdaLifecycleCRUD       = "CRUD"
+	lambdaLifecycleCreateOnly = "CREATE_ONLY"
+)
+
+// Success modes of the Lambda invocation
+const (
+	lambdaSuccessStatusCode      = "status_code"
+	lambdaSuccessNoFunctionError = "no_function_error"
+	lambdaSuccessJSONPath        = "jsonpath"
+)
+
+// Completion statuses of the Lambda invocation. Asynchronous invocations without a completion
+// parameter are only known to be submitted.
+const (
+	lambdaCompletionSucceeded = "SUCCEEDED"
+	lambdaCompletionFailed    = "FAILED"
+	lambdaCompletionPending   = "PENDING"
+	lambdaCompletionSubmitted = "SUBMITTED"
+)
+
+// Retry defaults used when the retry_on_failure block omits a value
+const (
+	defaultLambdaRetryAttempts = 3
+	defaultLambdaRetryBackoff  = 5 * time.Second
+)
 
 // NewExecuteAwsLambdaFunctionResource is a helper function to simplify the provider implementation.
 func NewExecuteAwsLambdaFunctionResource() resource.Resource {
@@ -31,15 +71,44 @@ func NewExecuteAwsLambdaFunctionResource() resource.Resource {
 }
 
 // executeAwsLambdaFunctionResource is the resource implementation.
-type executeAwsLambdaFunctionResource struct{}
+type executeAwsLambdaFunctionResource struct {
+	providerData *providerData
+}
 
 // executeAwsLambdaFunctionResourceModel maps the resource schema data.
 type executeAwsLambdaFunctionResourceModel struct {
-	ID                 types.String `tfsdk:"id"`
-	FunctionName       types.String `tfsdk:"function_name"`
-	Region             types.String `tfsdk:"region"`
-	SourceCodeHash     types.String `tfsdk:"source_code_hash"`
-	ExecutionSucceeded types.Bool   `tfsdk:"execution_succeeded"`
+	ID                  types.String      `tfsdk:"id"`
+	FunctionName        types.String      `tfsdk:"function_name"`
+	Region              types.String      `tfsdk:"region"`
+	SourceCodeHash      types.String      `tfsdk:"source_code_hash"`
+	Input               types.String      `tfsdk:"input"`
+	Qualifier           types.String      `tfsdk:"qualifier"`
+	InvocationType      types.String      `tfsdk:"invocation_type"`
+	LogType             types.String      `tfsdk:"log_type"`
+	Triggers            types.Map         `tfsdk:"triggers"`
+	OnDestroyInput      types.String      `tfsdk:"on_destroy_input"`
+	LifecycleScope      types.String      `tfsdk:"lifecycle_scope"`
+	SuccessMode         types.String      `tfsdk:"success_mode"`
+	SuccessJSONPath     types.String      `tfsdk:"success_jsonpath"`
+	SuccessValue        types.String      `tfsdk:"success_value"`
+	RetryOnFailure      *lambdaRetryModel `tfsdk:"retry_on_failure"`
+	CompletionParameter types.String      `tfsdk:"completion_parameter"`
+	ExecutionSucceeded  types.Bool        `tfsdk:"execution_succeeded"`
+	Result              types.String      `tfsdk:"result"`
+	StatusCode          types.Int64       `tfsdk:"status_code"`
+	FunctionError       types.String      `tfsdk:"function_error"`
+	ExecutedVersion     types.String      `tfsdk:"executed_version"`
+	LogResult           types.String      `tfsdk:"log_result"`
+	RequestID           types.String      `tfsdk:"request_id"`
+	CompletionStatus    types.String      `tfsdk:"completion_status"`
+	Duration            types.String      `tfsdk:"duration"`
+	Timeouts            timeouts.Value    `tfsdk:"timeouts"`
+}
+
+// lambdaRetryModel maps the retry_on_failure block.
+type lambdaRetryModel struct {
+	Attempts types.Int64  `tfsdk:"attempts"`
+	Backoff  types.String `tfsdk:"backoff"`
 }
 
 // Metadata returns the resource type name.
@@ -48,7 +117,7 @@ func (r *executeAwsLambdaFunctionResource) Metadata(_ context.Context, req resou
 }
 
 // Schema defines the schema for the resource.
-func (r *executeAwsLambdaFunctionResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *executeAwsLambdaFunctionResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description: "Executes an AWS Lambda function and returns the result.",
 		Attributes: map[string]schema.Attribute{
@@ -71,12 +140,135 @@ func (r *executeAwsLambdaFunctionResource) Schema(_ context.Context, _ resource.
 				Description: "The hash of the lambda zip that is being uploaded",
 				Required:    true,
 			},
+			"input": schema.StringAttribute{
+				Description: "JSON payload passed to the function. Defaults to an empty payload.",
+				Optional:    true,
+			},
+			"qualifier": schema.StringAttribute{
+				Description: "Version or alias of the function to invoke. Defaults to $LATEST.",
+				Optional:    true,
+			},
+			"invocation_type": schema.StringAttribute{
+				Description: "Invocation type: RequestResponse, Event or DryRun. Defaults to RequestResponse.",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(string(lambdatypes.InvocationTypeRequestResponse)),
+			},
+			"log_type": schema.StringAttribute{
+				Description: "Set to Tail to return the last 4 KB of the execution log in log_result. Only supported with RequestResponse.",
+				Optional:    true,
+			},
+			"triggers": schema.MapAttribute{
+				Description: "Arbitrary values that, when changed, replace the resource and invoke the function again.",
+				ElementType: types.StringType,
+				Optional:    true,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"on_destroy_input": schema.StringAttribute{
+				Description: "JSON payload of an invocation run when the resource is destroyed, for example to deregister what the create invocation registered. " +
+					"No invocation is run on destroy when unset.",
+				Optional: true,
+			},
+			"lifecycle_scope": schema.StringAttribute{
+				Description: "When the function is invoked: CRUD invokes it on create and on every update, CREATE_ONLY only on create " +
+					"and when triggers replace the resource. Defaults to CRUD.",
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString(lambdaLifecycleCRUD),
+			},
+			"success_mode": schema.StringAttribute{
+				Description: "How a synchronous invocation is judged successful: status_code requires a payload with a 2xx statusCode member, " +
+					"no_function_error only requires the function not to raise an error and jsonpath compares the value selected by success_jsonpath. " +
+					"Defaults to status_code.",
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString(lambdaSuccessStatusCode),
+			},
+			"success_jsonpath": schema.StringAttribute{
+				Description: "JSONPath expression, such as $.status or $.body['result'], evaluated against the payload when success_mode is jsonpath.",
+				Optional:    true,
+			},
+			"success_value": schema.StringAttribute{
+				Description: "Expected value selected by success_jsonpath. Non-string values are compared as compact JSON. " +
+					"When unset, the selected value must exist and not be null or false.",
+				Optional: true,
+			},
+			"completion_parameter": schema.StringAttribute{
+				Description: "Name of an SSM parameter the function writes when it finishes, as JSON " +
+					"{\"request_id\": context.aws_request_id, \"status\": \"SUCCEEDED\" or \"FAILED\", \"message\": optional}. " +
+					"With invocation_type Event the provider polls it until the invocation completes, fails or the timeout expires.",
+				Optional: true,
+			},
 			"execution_succeeded": schema.BoolAttribute{
 				Description: "Whether the Lambda function execution succeeded.",
 				Computed:    true,
 			},
+			"result": schema.StringAttribute{
+				Description: "Raw payload returned by the function.",
+				Computed:    true,
+			},
+			"status_code": schema.Int64Attribute{
+				Description: "HTTP status code of the invocation: 200 for RequestResponse, 202 for Event and 204 for DryRun.",
+				Computed:    true,
+			},
+			"function_error": schema.StringAttribute{
+				Description: "Type of error raised by the function, empty when the function succeeded.",
+				Computed:    true,
+			},
+			"executed_version": schema.StringAttribute{
+				Description: "Version of the function that was executed.",
+				Computed:    true,
+			},
+			"log_result": schema.StringAttribute{
+				Description: "Decoded tail of the execution log when log_type is Tail.",
+				Computed:    true,
+			},
+			"request_id": schema.StringAttribute{
+				Description: "Request ID of the invocation, also seen by the function as its aws_request_id.",
+				Computed:    true,
+			},
+			"completion_status": schema.StringAttribute{
+				Description: "SUCCEEDED once the invocation completed, or SUBMITTED for an Event invocation without completion_parameter.",
+				Computed:    true,
+			},
+			"duration": schema.StringAttribute{
+				Description: "Time from the invocation until its completion, for example 4m32s.",
+				Computed:    true,
+			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Update: true,
+				Delete: true,
+			}),
+			"retry_on_failure": schema.SingleNestedBlock{
+				Description: "Invoke the function again when an invocation fails or does not meet the success criteria.",
+				Attributes: map[string]schema.Attribute{
+					"attempts": schema.Int64Attribute{
+						Description: "Maximum number of invocations, including the first one. Defaults to 3.",
+						Optional:    true,
+					},
+					"backoff": schema.StringAttribute{
+						Description: "Delay before the first retry, doubled for every further retry, for example 10s. Defaults to 5s.",
+						Optional:    true,
+					},
+				},
+			},
+		},
+	}
+}
+
+// Configure stores the provider data used to build the Lambda client.
+func (r *executeAwsLambdaFunctionResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// If provider is not configured, return
+	if req.ProviderData == nil {
+		return
 	}
+
+	r.providerData = providerDataFrom(req.ProviderData, &resp.Diagnostics)
 }
 
 // Create creates the resource and sets the initial Terraform state.
@@ -89,23 +281,32 @@ func (r *executeAwsLambdaFunctionResource) Create(ctx context.Context, req resou
 		return
 	}
 
+	if !r.providerData.configured(&resp.Diagnostics) {
+		return
+	}
+
+	if err := checkLambdaLifecycleScope(plan.LifecycleScope.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("lifecycle_scope"), "Invalid lifecycle scope", err.Error())
+		return
+	}
+
+	timeout, diags := plan.Timeouts.Create(ctx, defaultWaitTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
 	// Execute the AWS Lambda function
-	success, err := executeLambdaFunction(
-		ctx,
-		plan.FunctionName.ValueString(),
-		plan.Region.ValueString(),
-	)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Failed to execute AWS Lambda function",
-			fmt.Sprintf("Failed to execute AWS Lambda function: %s", err),
-		)
+	r.invoke(ctx, &plan, timeout, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	// Set resource ID to function name
 	plan.ID = plan.FunctionName
-	plan.ExecutionSucceeded = types.BoolValue(success)
 
 	// Set state to fully populated data
 	diags = resp.State.Set(ctx, plan)
@@ -135,29 +336,52 @@ func (r *executeAwsLambdaFunctionResource) Read(ctx context.Context, req resourc
 
 // Update updates the resource and sets the updated Terraform state on success.
 func (r *executeAwsLambdaFunctionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	// Retrieve values from plan
-	var plan executeAwsLambdaFunctionResourceModel
+	// Retrieve values from plan and state
+	var plan, state executeAwsLambdaFunctionResourceModel
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	// Execute the AWS Lambda function
-	success, err := executeLambdaFunction(
-		ctx,
-		plan.FunctionName.ValueString(),
-		plan.Region.ValueString(),
-	)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Failed to execute AWS Lambda function",
-			fmt.Sprintf("Failed to execute AWS Lambda function: %s", err),
-		)
+	if !r.providerData.configured(&resp.Diagnostics) {
 		return
 	}
 
-	plan.ExecutionSucceeded = types.BoolValue(success)
+	if err := checkLambdaLifecycleScope(plan.LifecycleScope.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("lifecycle_scope"), "Invalid lifecycle scope", err.Error())
+		return
+	}
+
+	if plan.LifecycleScope.ValueString() == lambdaLifecycleCreateOnly {
+		// Keep the outputs of the create invocation
+		tflog.Info(ctx, "Skipping Lambda invocation on update", map[string]interface{}{"lifecycle_scope": lambdaLifecycleCreateOnly})
+		plan.ExecutionSucceeded = state.ExecutionSucceeded
+		plan.Result = state.Result
+		plan.StatusCode = state.StatusCode
+		plan.FunctionError = state.FunctionError
+		plan.ExecutedVersion = state.ExecutedVersion
+		plan.LogResult = state.LogResult
+		plan.RequestID = state.RequestID
+		plan.CompletionStatus = state.CompletionStatus
+		plan.Duration = state.Duration
+	} else {
+		timeout, diags := plan.Timeouts.Update(ctx, defaultWaitTimeout)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+
+		// Execute the AWS Lambda function
+		r.invoke(ctx, &plan, timeout, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
 
 	// Set state
 	diags = resp.State.Set(ctx, plan)
@@ -176,6 +400,29 @@ func (r *executeAwsLambdaFunctionResource) Delete(ctx context.Context, req resou
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	// Nothing to undo unless a teardown payload is configured
+	if state.OnDestroyInput.IsNull() {
+		return
+	}
+
+	if !r.providerData.configured(&resp.Diagnostics) {
+		return
+	}
+
+	timeout, diags := state.Timeouts.Delete(ctx, defaultWaitTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	tflog.Info(ctx, "Invoking Lambda function for teardown", map[string]interface{}{"function_name": state.FunctionName.ValueString()})
+	teardown := state
+	teardown.Input = state.OnDestroyInput
+	r.invoke(ctx, &teardown, timeout, &resp.Diagnostics)
 }
 
 // ImportState imports the resource into Terraform state.
@@ -183,67 +430,377 @@ func (r *executeAwsLambdaFunctionResource) ImportState(ctx context.Context, req
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
 
-type lambdaResultPayload struct {
-	StatusCode int `json:"statusCode"`
-}
+// invoke executes the function with the planned input, retrying when configured, and records the response in the plan
+func (r *executeAwsLambdaFunctionResource) invoke(ctx context.Context, plan *executeAwsLambdaFunctionResourceModel, timeout time.Duration, diags *diag.Diagnostics) {
+	input, err := lambdaInvokeInput(plan)
+	if err != nil {
+		diags.AddError("Invalid Lambda invocation", err.Error())
+		return
+	}
 
-// executeLambdaFunction executes the AWS Lambda function using the AWS SDK and returns the result.
-func executeLambdaFunction(ctx context.Context, functionName, region string) (bool, error) {
-	tflog.Info(ctx, fmt.Sprintf("Invoking Lambda function %s...", functionName))
+	criteria, err := lambdaSuccessCriteriaFrom(plan)
+	if err != nil {
+		diags.AddError("Invalid Lambda success criteria", err.Error())
+		return
+	}
 
-	// Load AWS configuration
-	cfg, err := config.LoadDefaultConfig(ctx, config.WithRegion(region))
+	attempts, backoff, err := lambdaRetrySettings(plan.RetryOnFailure)
 	if err != nil {
-		tflog.Error(ctx, fmt.Sprintf("Error loading AWS config: %s", err))
-		return false, err
+		diags.AddAttributeError(path.Root("retry_on_failure"), "Invalid Lambda retry settings", err.Error())
+		return
+	}
+
+	client := r.providerData.lambdaClient(plan.Region.ValueString())
+	start := time.Now()
+
+	var result *lambdaInvocationResult
+	for attempt := 1; ; attempt++ {
+		result, err = executeLambdaFunction(ctx, client, input, criteria)
+		if err == nil || attempt >= attempts {
+			break
+		}
+
+		delay := backoff << (attempt - 1)
+		tflog.Warn(ctx, "Lambda execution failed, retrying", map[string]interface{}{
+			"attempt": attempt,
+			"delay":   delay.String(),
+			"error":   err.Error(),
+		})
+		select {
+		case <-ctx.Done():
+			err = fmt.Errorf("%w (last error: %s)", ctx.Err(), err)
+		case <-time.After(delay):
+			continue
+		}
+		break
 	}
 
-	// Create Lambda client
-	lambdaClient := lambdasvc.NewFromConfig(cfg)
+	if result != nil {
+		plan.Result = types.StringValue(result.Payload)
+		plan.StatusCode = types.Int64Value(int64(result.StatusCode))
+		plan.FunctionError = types.StringValue(result.FunctionError)
+		plan.ExecutedVersion = types.StringValue(result.ExecutedVersion)
+		plan.LogResult = types.StringValue(result.LogResult)
+		plan.RequestID = types.StringValue(result.RequestID)
+	}
+	if err != nil {
+		diags.AddError(
+			"Failed to execute AWS Lambda function",
+			fmt.Sprintf("Failed to execute AWS Lambda function: %s", err),
+		)
+		return
+	}
+
+	status := lambdaCompletionSucceeded
+	if input.InvocationType == lambdatypes.InvocationTypeEvent {
+		status = lambdaCompletionSubmitted
+		if parameterName := plan.CompletionParameter.ValueString(); parameterName != "" {
+			ssmClient := r.providerData.ssmClient(plan.Region.ValueString())
+			if status, err = waitForLambdaCompletion(ctx, ssmClient, parameterName, result.RequestID, timeout); err != nil {
+				diags.AddError(
+					"Failed to execute AWS Lambda function",
+					fmt.Sprintf("Asynchronous Lambda invocation %s did not complete: %s", result.RequestID, err),
+				)
+				return
+			}
+		}
+	}
+
+	plan.ExecutionSucceeded = types.BoolValue(true)
+	plan.CompletionStatus = types.StringValue(status)
+	plan.Duration = types.StringValue(time.Since(start).Round(time.Second).String())
+}
+
+// waitForLambdaCompletion polls the completion parameter until the function reports the outcome
+// of the invocation with the given request ID
+func waitForLambdaCompletion(ctx context.Context, client *ssm.Client, parameterName, requestID string, timeout time.Duration) (string, error) {
+	message := ""
+	poller := &statusPoller{
+		Resource: fmt.Sprintf("Lambda invocation %s", requestID),
+		Target:   []string{lambdaCompletionSucceeded},
+		Failure:  []string{lambdaCompletionFailed},
+		Timeout:  timeout,
+		Refresh: func(ctx context.Context) (string, error) {
+			out, err := client.GetParameter(ctx, &ssm.GetParameterInput{
+				Name:           aws.String(parameterName),
+				WithDecryption: aws.Bool(true),
+			})
+			var notFound *ssmtypes.ParameterNotFound
+			if errors.As(err, &notFound) {
+				return lambdaCompletionPending, nil
+			}
+			if err != nil {
+				return "", fmt.Errorf("error reading completion parameter %s: %w", parameterName, err)
+			}
+
+			var status string
+			status, message = parseLambdaCompletion(aws.ToString(out.Parameter.Value), requestID)
+			return status, nil
+		},
+		MinInterval: 10 * time.Second,
+		MaxInterval: time.Minute,
+	}
+
+	status, err := poller.Wait(ctx)
+	if err != nil && message != "" {
+		return status, fmt.Errorf("%w: %s", err, message)
+	}
+	return status, err
+}
+
+// lambdaCompletionSignal is the document a function writes to its completion parameter
+type lambdaCompletionSignal struct {
+	RequestID string `json:"request_id"`
+	Status    string `json:"status"`
+	Message   string `json:"message"`
+}
+
+// parseLambdaCompletion returns the status and message reported for the invocation. Values left by
+// other invocations or that cannot be parsed are reported as pending.
+func parseLambdaCompletion(value, requestID string) (string, string) {
+	var signal lambdaCompletionSignal
+	if err := json.Unmarshal([]byte(value), &signal); err != nil || signal.RequestID != requestID {
+		return lambdaCompletionPending, ""
+	}
+
+	status := strings.ToUpper(strings.TrimSpace(signal.Status))
+	if status == "" {
+		status = lambdaCompletionPending
+	}
+	return status, signal.Message
+}
+
+// lambdaRetrySettings returns the number of attempts and the initial backoff of the retry_on_failure block
+func lambdaRetrySettings(retry *lambdaRetryModel) (int, time.Duration, error) {
+	if retry == nil {
+		return 1, 0, nil
+	}
+
+	attempts := int64(defaultLambdaRetryAttempts)
+	if !retry.Attempts.IsNull() {
+		attempts = retry.Attempts.ValueInt64()
+	}
+	if attempts < 1 {
+		return 0, 0, fmt.Errorf("attempts must be at least 1, got %d", attempts)
+	}
+
+	backoff := defaultLambdaRetryBackoff
+	if !retry.Backoff.IsNull() {
+		var err error
+		if backoff, err = time.ParseDuration(retry.Backoff.ValueString()); err != nil {
+			return 0, 0, fmt.Errorf("invalid backoff: %w", err)
+		}
+		if backoff < 0 {
+			return 0, 0, fmt.Errorf("backoff must not be negative, got %s", backoff)
+		}
+	}
+
+	return int(attempts), backoff, nil
+}
+
+// checkLambdaLifecycleScope validates the lifecycle_scope value
+func checkLambdaLifecycleScope(scope string) error {
+	if scope != lambdaLifecycleCRUD && scope != lambdaLifecycleCreateOnly {
+		return fmt.Errorf("lifecycle_scope must be %s or %s, got %q", lambdaLifecycleCRUD, lambdaLifecycleCreateOnly, scope)
+	}
+	return nil
+}
 
-	// Prepare the invoke input
+// lambdaInvokeInput builds the Invoke request from the resource configuration
+func lambdaInvokeInput(plan *executeAwsLambdaFunctionResourceModel) (*lambdasvc.InvokeInput, error) {
 	input := &lambdasvc.InvokeInput{
-		FunctionName: aws.String(functionName),
+		FunctionName:   aws.String(plan.FunctionName.ValueString()),
+		InvocationType: lambdatypes.InvocationTypeRequestResponse,
+	}
+
+	if !plan.InvocationType.IsNull() && !plan.InvocationType.IsUnknown() {
+		input.InvocationType = lambdatypes.InvocationType(plan.InvocationType.ValueString())
+		if !slices.Contains(input.InvocationType.Values(), input.InvocationType) {
+			return nil, fmt.Errorf("invocation_type must be one of RequestResponse, Event or DryRun, got %q", input.InvocationType)
+		}
+	}
+
+	if !plan.Input.IsNull() {
+		if !json.Valid([]byte(plan.Input.ValueString())) {
+			return nil, errors.New("input must be a valid JSON document")
+		}
+		input.Payload = []byte(plan.Input.ValueString())
+	}
+
+	if !plan.Qualifier.IsNull() {
+		input.Qualifier = aws.String(plan.Qualifier.ValueString())
 	}
 
+	if !plan.CompletionParameter.IsNull() && input.InvocationType != lambdatypes.InvocationTypeEvent {
+		return nil, errors.New("completion_parameter is only supported with invocation_type Event")
+	}
+
+	if !plan.LogType.IsNull() {
+		input.LogType = lambdatypes.LogType(plan.LogType.ValueString())
+		if !slices.Contains(input.LogType.Values(), input.LogType) {
+			return nil, fmt.Errorf("log_type must be None or Tail, got %q", input.LogType)
+		}
+		if input.LogType == lambdatypes.LogTypeTail && input.InvocationType != lambdatypes.InvocationTypeRequestResponse {
+			return nil, errors.New("log_type Tail is only supported with invocation_type RequestResponse")
+		}
+	}
+
+	return input, nil
+}
+
+// lambdaSuccessCriteria describes when a synchronous invocation is considered successful
+type lambdaSuccessCriteria struct {
+	Mode     string
+	JSONPath string
+	Value    *string
+}
+
+// lambdaSuccessCriteriaFrom builds the success criteria from the resource configuration
+func lambdaSuccessCriteriaFrom(plan *executeAwsLambdaFunctionResourceModel) (lambdaSuccessCriteria, error) {
+	criteria := lambdaSuccessCriteria{Mode: lambdaSuccessStatusCode}
+	if !plan.SuccessMode.IsNull() && !plan.SuccessMode.IsUnknown() {
+		criteria.Mode = plan.SuccessMode.ValueString()
+	}
+
+	switch criteria.Mode {
+	case lambdaSuccessStatusCode, lambdaSuccessNoFunctionError:
+	case lambdaSuccessJSONPath:
+		if plan.SuccessJSONPath.ValueString() == "" {
+			return criteria, errors.New("success_jsonpath is required when success_mode is jsonpath")
+		}
+		if _, err := parseJSONPath(plan.SuccessJSONPath.ValueString()); err != nil {
+			return criteria, err
+		}
+		criteria.JSONPath = plan.SuccessJSONPath.ValueString()
+		criteria.Value = plan.SuccessValue.ValueStringPointer()
+	default:
+		return criteria, fmt.Errorf("success_mode must be one of %s, %s or %s, got %q",
+			lambdaSuccessStatusCode, lambdaSuccessNoFunctionError, lambdaSuccessJSONPath, criteria.Mode)
+	}
+	return criteria, nil
+}
+
+// lambdaInvocationResult holds the response of a Lambda invocation
+type lambdaInvocationResult struct {
+	RequestID       string
+	StatusCode      int32
+	FunctionError   string
+	ExecutedVersion string
+	Payload         string
+	LogResult       string
+}
+
+type lambdaResultPayload struct {
+	StatusCode int `json:"statusCode"`
+}
+
+// executeLambdaFunction executes the AWS Lambda function using the AWS SDK and returns the result.
+// The result is also returned when the execution failed so that it can be recorded.
+func executeLambdaFunction(ctx context.Context, lambdaClient *lambdasvc.Client, input *lambdasvc.InvokeInput, criteria lambdaSuccessCriteria) (*lambdaInvocationResult, error) {
+	functionName := aws.ToString(input.FunctionName)
+	tflog.Info(ctx, fmt.Sprintf("Invoking Lambda function %s...", functionName), map[string]interface{}{
+		"invocation_type": string(input.InvocationType),
+		"qualifier":       aws.ToString(input.Qualifier),
+	})
+
 	// Invoke the Lambda function
-	result, err := lambdaClient.Invoke(ctx, input)
+	output, err := lambdaClient.Invoke(ctx, input)
 	if err != nil {
 		tflog.Error(ctx, fmt.Sprintf("Error invoking Lambda function: %s", err))
-		return false, err
+		return nil, err
 	}
 
-	// Process the response
-	responsePayload := string(result.Payload)
-
-	lrp := new(lambdaResultPayload)
-	if err = json.Unmarshal([]byte(responsePayload), lrp); err != nil {
-		tflog.Error(ctx, fmt.Sprintf("failed to parse payload: %s", err))
-		return false, err
+	result, err := lambdaInvocationResultFrom(output)
+	if err != nil {
+		return nil, err
 	}
 
 	// Format the result for output
 	var formattedResult strings.Builder
-	fmt.Fprintf(&formattedResult, "StatusCode: %d\n", lrp.StatusCode)
+	fmt.Fprintf(&formattedResult, "StatusCode: %d\n", result.StatusCode)
+	if result.FunctionError != "" {
+		fmt.Fprintf(&formattedResult, "FunctionError: %s\n", result.FunctionError)
+	}
+	fmt.Fprintf(&formattedResult, "ExecutedVersion: %s\n", result.ExecutedVersion)
+	fmt.Fprintf(&formattedResult, "Payload: %s\n", result.Payload)
+	if result.LogResult != "" {
+		fmt.Fprintf(&formattedResult, "\nLog:\n%s\n", result.LogResult)
+	}
+	tflog.Debug(ctx, formattedResult.String())
 
-	if result.FunctionError != nil {
-		fmt.Fprintf(&formattedResult, "FunctionError: %s\n", *result.FunctionError)
+	if err := checkLambdaResult(criteria, input.InvocationType, result); err != nil {
+		tflog.Error(ctx, "Lambda execution failed")
+		return result, err
 	}
 
-	fmt.Fprintf(&formattedResult, "ExecutedVersion: %s\n", aws.ToString(result.ExecutedVersion))
-	fmt.Fprintf(&formattedResult, "Payload: %s\n", responsePayload)
-	fmt.Fprintf(&formattedResult, "\nFormatted Payload:\n%s\n", string(result.Payload))
+	tflog.Info(ctx, fmt.Sprintf("Lambda execution completed with status code %d", result.StatusCode))
+	return result, nil
+}
 
-	// Check if the function executed successfully
-	success := lrp.StatusCode >= 200 && lrp.StatusCode < 300 && result.FunctionError == nil
+// lambdaInvocationResultFrom copies the Invoke output, decoding the base64 encoded log tail
+func lambdaInvocationResultFrom(output *lambdasvc.InvokeOutput) (*lambdaInvocationResult, error) {
+	requestID, _ := awsmiddleware.GetRequestIDMetadata(output.ResultMetadata)
+	result := &lambdaInvocationResult{
+		RequestID:       requestID,
+		StatusCode:      output.StatusCode,
+		FunctionError:   aws.ToString(output.FunctionError),
+		ExecutedVersion: aws.ToString(output.ExecutedVersion),
+		Payload:         string(output.Payload),
+	}
 
-	tflog.Info(ctx, fmt.Sprintf("Lambda execution completed with status code %d", lrp.StatusCode))
+	if output.LogResult != nil {
+		logResult, err := base64.StdEncoding.DecodeString(*output.LogResult)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode log result: %w", err)
+		}
+		result.LogResult = string(logResult)
+	}
+	return result, nil
+}
 
-	tflog.Debug(ctx, formattedResult.String())
-	if !success {
-		tflog.Error(ctx, "Lambda execution failed")
-		return false, fmt.Errorf("lambda execution failed with status code %d", lrp.StatusCode)
+// checkLambdaResult reports whether the invocation succeeded. Asynchronous and dry run invocations
+// only report the Invoke status, synchronous invocations are judged by the success criteria.
+func checkLambdaResult(criteria lambdaSuccessCriteria, invocationType lambdatypes.InvocationType, result *lambdaInvocationResult) error {
+	if result.FunctionError != "" {
+		return fmt.Errorf("lambda execution failed with function error %s: %s", result.FunctionError, result.Payload)
 	}
 
-	return success, nil
+	if invocationType != lambdatypes.InvocationTypeRequestResponse {
+		if result.StatusCode < 200 || result.StatusCode >= 300 {
+			return fmt.Errorf("lambda invocation failed with status code %d", result.StatusCode)
+		}
+		return nil
+	}
+
+	switch criteria.Mode {
+	case lambdaSuccessNoFunctionError:
+		return nil
+	case lambdaSuccessJSONPath:
+		value, found, err := evaluateJSONPath([]byte(result.Payload), criteria.JSONPath)
+		if err != nil {
+			return err
+		}
+		if !found {
+			return fmt.Errorf("lambda payload has no value at %s", criteria.JSONPath)
+		}
+		if criteria.Value == nil {
+			if value == nil || value == false {
+				return fmt.Errorf("lambda payload value at %s is %s", criteria.JSONPath, jsonPathValueString(value))
+			}
+			return nil
+		}
+		if got := jsonPathValueString(value); got != *criteria.Value {
+			return fmt.Errorf("lambda payload value at %s is %s, expected %s", criteria.JSONPath, got, *criteria.Value)
+		}
+		return nil
+	default:
+		lrp := new(lambdaResultPayload)
+		if err := json.Unmarshal([]byte(result.Payload), lrp); err != nil {
+			return fmt.Errorf("failed to parse payload: %w", err)
+		}
+		if lrp.StatusCode < 200 || lrp.StatusCode >= 300 {
+			return fmt.Errorf("lambda execution failed with status code %d", lrp.StatusCode)
+		}
+		return nil
+	}
 }
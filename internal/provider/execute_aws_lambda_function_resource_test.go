@@ -0,0 +1,216 @@
+// Copyright (c) IBM Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	lambdasvc "github.com/aws/aws-sdk-go-v2/service/lambda"
+	lambdatypes "github.com/aws/aws-sdk-go-v2/service/lambda/types"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func testLambdaPlan() *executeAwsLambdaFunctionResourceModel {
+	return &executeAwsLambdaFunctionResourceModel{
+		FunctionName:   types.StringValue("guardium-bootstrap"),
+		InvocationType: types.StringValue("RequestResponse"),
+	}
+}
+
+func TestLambdaInvokeInput(t *testing.T) {
+	plan := testLambdaPlan()
+	plan.Input = types.StringValue(`{"datasource":"orders"}`)
+	plan.Qualifier = types.StringValue("live")
+	plan.LogType = types.StringValue("Tail")
+
+	input, err := lambdaInvokeInput(plan)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if string(input.Payload) != `{"datasource":"orders"}` || aws.ToString(input.Qualifier) != "live" {
+		t.Errorf("unexpected input %+v", input)
+	}
+	if input.InvocationType != lambdatypes.InvocationTypeRequestResponse || input.LogType != lambdatypes.LogTypeTail {
+		t.Errorf("unexpected invocation or log type %s/%s", input.InvocationType, input.LogType)
+	}
+}
+
+func TestLambdaInvokeInputErrors(t *testing.T) {
+	cases := map[string]func(*executeAwsLambdaFunctionResourceModel){
+		"invalid json":            func(p *executeAwsLambdaFunctionResourceModel) { p.Input = types.StringValue("{") },
+		"invalid invocation type": func(p *executeAwsLambdaFunctionResourceModel) { p.InvocationType = types.StringValue("Async") },
+		"invalid log type":        func(p *executeAwsLambdaFunctionResourceModel) { p.LogType = types.StringValue("Full") },
+		"tail with event": func(p *executeAwsLambdaFunctionResourceModel) {
+			p.InvocationType = types.StringValue("Event")
+			p.LogType = types.StringValue("Tail")
+		},
+	}
+
+	for name, mutate := range cases {
+		t.Run(name, func(t *testing.T) {
+			plan := testLambdaPlan()
+			mutate(plan)
+			if _, err := lambdaInvokeInput(plan); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+func TestLambdaInvocationResultFrom(t *testing.T) {
+	output := &lambdasvc.InvokeOutput{
+		StatusCode:      200,
+		ExecutedVersion: aws.String("3"),
+		Payload:         []byte(`{"statusCode":200}`),
+		LogResult:       aws.String(base64.StdEncoding.EncodeToString([]byte("START RequestId: 1\nEND RequestId: 1\n"))),
+	}
+
+	result, err := lambdaInvocationResultFrom(output)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if result.LogResult != "START RequestId: 1\nEND RequestId: 1\n" || result.ExecutedVersion != "3" {
+		t.Errorf("unexpected result %+v", result)
+	}
+
+	output.LogResult = aws.String("not base64!")
+	if _, err := lambdaInvocationResultFrom(output); err == nil {
+		t.Error("expected a decode error")
+	}
+}
+
+func TestCheckLambdaResult(t *testing.T) {
+	sync := lambdatypes.InvocationTypeRequestResponse
+	legacy := lambdaSuccessCriteria{Mode: lambdaSuccessStatusCode}
+	if err := checkLambdaResult(legacy, sync, &lambdaInvocationResult{StatusCode: 200, Payload: `{"statusCode":201}`}); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+	if err := checkLambdaResult(legacy, sync, &lambdaInvocationResult{StatusCode: 200, Payload: `{"statusCode":500}`}); err == nil {
+		t.Error("expected a status code error")
+	}
+	if err := checkLambdaResult(legacy, sync, &lambdaInvocationResult{StatusCode: 200, FunctionError: "Unhandled", Payload: `{}`}); err == nil {
+		t.Error("expected a function error")
+	}
+	if err := checkLambdaResult(legacy, lambdatypes.InvocationTypeEvent, &lambdaInvocationResult{StatusCode: 202}); err != nil {
+		t.Errorf("unexpected error for an event invocation: %s", err)
+	}
+}
+
+func TestCheckLambdaLifecycleScope(t *testing.T) {
+	for _, scope := range []string{lambdaLifecycleCRUD, lambdaLifecycleCreateOnly} {
+		if err := checkLambdaLifecycleScope(scope); err != nil {
+			t.Errorf("unexpected error for %s: %s", scope, err)
+		}
+	}
+	if err := checkLambdaLifecycleScope("crud"); err == nil {
+		t.Error("expected an error for an unknown scope")
+	}
+}
+
+func TestCheckLambdaResultModes(t *testing.T) {
+	sync := lambdatypes.InvocationTypeRequestResponse
+	ok := "ok"
+	cases := map[string]struct {
+		criteria lambdaSuccessCriteria
+		payload  string
+		wantErr  bool
+	}{
+		"plain string":       {criteria: lambdaSuccessCriteria{Mode: lambdaSuccessNoFunctionError}, payload: `"done"`},
+		"null":               {criteria: lambdaSuccessCriteria{Mode: lambdaSuccessNoFunctionError}, payload: `null`},
+		"jsonpath match":     {criteria: lambdaSuccessCriteria{Mode: lambdaSuccessJSONPath, JSONPath: "$.status", Value: &ok}, payload: `{"status":"ok"}`},
+		"jsonpath mismatch":  {criteria: lambdaSuccessCriteria{Mode: lambdaSuccessJSONPath, JSONPath: "$.status", Value: &ok}, payload: `{"status":"failed"}`, wantErr: true},
+		"jsonpath missing":   {criteria: lambdaSuccessCriteria{Mode: lambdaSuccessJSONPath, JSONPath: "$.status"}, payload: `{}`, wantErr: true},
+		"jsonpath truthy":    {criteria: lambdaSuccessCriteria{Mode: lambdaSuccessJSONPath, JSONPath: "$.ready"}, payload: `{"ready":true}`},
+		"jsonpath false":     {criteria: lambdaSuccessCriteria{Mode: lambdaSuccessJSONPath, JSONPath: "$.ready"}, payload: `{"ready":false}`, wantErr: true},
+		"status code string": {criteria: lambdaSuccessCriteria{Mode: lambdaSuccessStatusCode}, payload: `"done"`, wantErr: true},
+		"jsonpath not json":  {criteria: lambdaSuccessCriteria{Mode: lambdaSuccessJSONPath, JSONPath: "$.status"}, payload: `done`, wantErr: true},
+		"jsonpath number":    {criteria: lambdaSuccessCriteria{Mode: lambdaSuccessJSONPath, JSONPath: "$.count", Value: new(string)}, payload: `{"count":0}`, wantErr: true},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := checkLambdaResult(tc.criteria, sync, &lambdaInvocationResult{StatusCode: 200, Payload: tc.payload})
+			if (err != nil) != tc.wantErr {
+				t.Errorf("expected error %t, got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestLambdaSuccessCriteriaFrom(t *testing.T) {
+	plan := testLambdaPlan()
+	plan.SuccessMode = types.StringValue(lambdaSuccessJSONPath)
+	if _, err := lambdaSuccessCriteriaFrom(plan); err == nil {
+		t.Error("expected an error without success_jsonpath")
+	}
+
+	plan.SuccessJSONPath = types.StringValue("$.body['status']")
+	plan.SuccessValue = types.StringValue("ok")
+	criteria, err := lambdaSuccessCriteriaFrom(plan)
+	if err != nil || criteria.Value == nil || *criteria.Value != "ok" {
+		t.Errorf("unexpected criteria %+v, %v", criteria, err)
+	}
+
+	plan.SuccessMode = types.StringValue("http")
+	if _, err := lambdaSuccessCriteriaFrom(plan); err == nil {
+		t.Error("expected an error for an unknown mode")
+	}
+}
+
+func TestLambdaRetrySettings(t *testing.T) {
+	if attempts, _, err := lambdaRetrySettings(nil); err != nil || attempts != 1 {
+		t.Errorf("expected a single attempt without retry block, got %d, %v", attempts, err)
+	}
+
+	attempts, backoff, err := lambdaRetrySettings(&lambdaRetryModel{Attempts: types.Int64Null(), Backoff: types.StringNull()})
+	if err != nil || attempts != defaultLambdaRetryAttempts || backoff != defaultLambdaRetryBackoff {
+		t.Errorf("unexpected defaults %d, %s, %v", attempts, backoff, err)
+	}
+
+	if _, _, err := lambdaRetrySettings(&lambdaRetryModel{Attempts: types.Int64Value(0), Backoff: types.StringNull()}); err == nil {
+		t.Error("expected an error for zero attempts")
+	}
+	if _, _, err := lambdaRetrySettings(&lambdaRetryModel{Attempts: types.Int64Null(), Backoff: types.StringValue("soon")}); err == nil {
+		t.Error("expected an error for an invalid backoff")
+	}
+}
+
+func TestParseLambdaCompletion(t *testing.T) {
+	cases := map[string]struct {
+		value       string
+		wantStatus  string
+		wantMessage string
+	}{
+		"succeeded":     {value: `{"request_id":"abc","status":"succeeded"}`, wantStatus: lambdaCompletionSucceeded},
+		"failed":        {value: `{"request_id":"abc","status":"FAILED","message":"no route to host"}`, wantStatus: lambdaCompletionFailed, wantMessage: "no route to host"},
+		"running":       {value: `{"request_id":"abc","status":"RUNNING"}`, wantStatus: "RUNNING"},
+		"other request": {value: `{"request_id":"old","status":"SUCCEEDED"}`, wantStatus: lambdaCompletionPending},
+		"no status":     {value: `{"request_id":"abc"}`, wantStatus: lambdaCompletionPending},
+		"not json":      {value: `SUCCEEDED`, wantStatus: lambdaCompletionPending},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			status, message := parseLambdaCompletion(tc.value, "abc")
+			if status != tc.wantStatus || message != tc.wantMessage {
+				t.Errorf("expected %s/%q, got %s/%q", tc.wantStatus, tc.wantMessage, status, message)
+			}
+		})
+	}
+}
+
+func TestLambdaInvokeInputCompletionParameter(t *testing.T) {
+	plan := testLambdaPlan()
+	plan.CompletionParameter = types.StringValue("/guardium/bootstrap/status")
+	if _, err := lambdaInvokeInput(plan); err == nil {
+		t.Error("expected an error for a synchronous invocation with completion_parameter")
+	}
+
+	plan.InvocationType = types.StringValue("Event")
+	if _, err := lambdaInvokeInput(plan); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+}
@@ -0,0 +1,109 @@
+// Copyright (c) IBM Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// evaluateJSONPath returns the value selected by a simple JSONPath expression from a JSON document.
+// Only the dot and bracket child operators are supported, for example $.body.status,
+// $['status'] or $.items[0].name. A missing member is reported with found set to false.
+func evaluateJSONPath(document []byte, expression string) (value any, found bool, err error) {
+	steps, err := parseJSONPath(expression)
+	if err != nil {
+		return nil, false, err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(document))
+	decoder.UseNumber()
+	if err := decoder.Decode(&value); err != nil {
+		return nil, false, fmt.Errorf("payload is not a JSON document: %w", err)
+	}
+
+	for _, step := range steps {
+		switch current := value.(type) {
+		case map[string]any:
+			if value, found = current[step]; !found {
+				return nil, false, nil
+			}
+		case []any:
+			index, err := strconv.Atoi(step)
+			if err != nil || index < 0 || index >= len(current) {
+				return nil, false, nil
+			}
+			value = current[index]
+		default:
+			return nil, false, nil
+		}
+	}
+	return value, true, nil
+}
+
+// parseJSONPath splits a JSONPath expression into member names and array indexes
+func parseJSONPath(expression string) ([]string, error) {
+	rest, ok := strings.CutPrefix(strings.TrimSpace(expression), "$")
+	if !ok {
+		return nil, fmt.Errorf("JSONPath %q must start with $", expression)
+	}
+
+	var steps []string
+	for rest != "" {
+		switch rest[0] {
+		case '.':
+			end := strings.IndexAny(rest[1:], ".[")
+			if end < 0 {
+				end = len(rest) - 1
+			}
+			name := rest[1 : end+1]
+			if name == "" {
+				return nil, fmt.Errorf("JSONPath %q has an empty member name", expression)
+			}
+			steps = append(steps, name)
+			rest = rest[end+1:]
+		case '[':
+			end := strings.IndexByte(rest, ']')
+			if end < 0 {
+				return nil, fmt.Errorf("JSONPath %q has an unterminated bracket", expression)
+			}
+			step := rest[1:end]
+			if unquoted, err := unquoteJSONPathMember(step); err == nil {
+				step = unquoted
+			} else if _, err := strconv.Atoi(step); err != nil {
+				return nil, fmt.Errorf("JSONPath %q has an unsupported selector [%s]", expression, step)
+			}
+			steps = append(steps, step)
+			rest = rest[end+1:]
+		default:
+			return nil, fmt.Errorf("JSONPath %q has an unexpected character %q", expression, rest[0])
+		}
+	}
+	return steps, nil
+}
+
+// unquoteJSONPathMember strips the quotes of a bracketed member name such as ['status']
+func unquoteJSONPathMember(step string) (string, error) {
+	if len(step) >= 2 && (step[0] == '\'' || step[0] == '"') && step[len(step)-1] == step[0] {
+		return step[1 : len(step)-1], nil
+	}
+	return "", errors.New("not a quoted member name")
+}
+
+// jsonPathValueString renders a selected value for comparison: strings are returned as is,
+// everything else as compact JSON
+func jsonPathValueString(value any) string {
+	if s, ok := value.(string); ok {
+		return s
+	}
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(encoded)
+}
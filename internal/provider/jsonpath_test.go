@@ -0,0 +1,51 @@
+// Copyright (c) IBM Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import "testing"
+
+func TestEvaluateJSONPath(t *testing.T) {
+	document := []byte(`{"status":"ok","body":{"items":[{"name":"orders"},{"name":"users"}],"count":2,"ready":true},"it's":1}`)
+
+	cases := map[string]struct {
+		want  string
+		found bool
+	}{
+		"$.body.items":         {want: `[{"name":"orders"},{"name":"users"}]`, found: true},
+		"$.status":             {want: "ok", found: true},
+		"$['status']":          {want: "ok", found: true},
+		`$["it's"]`:            {want: "1", found: true},
+		"$.body.count":         {want: "2", found: true},
+		"$.body.ready":         {want: "true", found: true},
+		"$.body.items[1].name": {want: "users", found: true},
+		"$.body['items'][0]":   {want: `{"name":"orders"}`, found: true},
+		"$.missing":            {},
+		"$.body.items[5]":      {},
+		"$.status.nested":      {},
+		"$.body.items.name":    {},
+	}
+
+	for expression, tc := range cases {
+		t.Run(expression, func(t *testing.T) {
+			value, found, err := evaluateJSONPath(document, expression)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if found != tc.found {
+				t.Fatalf("expected found %t, got %t", tc.found, found)
+			}
+			if found && jsonPathValueString(value) != tc.want {
+				t.Errorf("expected %s, got %s", tc.want, jsonPathValueString(value))
+			}
+		})
+	}
+}
+
+func TestParseJSONPathErrors(t *testing.T) {
+	for _, expression := range []string{"status", "$..status", "$[status]", "$['status'", "$status"} {
+		if _, err := parseJSONPath(expression); err == nil {
+			t.Errorf("expected an error for %q", expression)
+		}
+	}
+}
@@ -0,0 +1,317 @@
+// Copyright (c) IBM Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	// mysqlAuditPluginName is the name of the MariaDB audit plugin in INFORMATION_SCHEMA.PLUGINS
+	mysqlAuditPluginName = "SERVER_AUDIT"
+	// mysqlAllPrivileges is reported by SHOW GRANTS for GRANT ALL
+	mysqlAllPrivileges = "ALL PRIVILEGES"
+	// defaultMySQLUserHost is the host part of the checked account when user_host is not set
+	defaultMySQLUserHost = "%"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var _ datasource.DataSource = &mysqlAuditCheckDataSource{}
+var _ datasource.DataSourceWithConfigure = &mysqlAuditCheckDataSource{}
+
+// NewMySQLAuditCheckDataSource is a helper function to simplify the provider implementation.
+func NewMySQLAuditCheckDataSource() datasource.DataSource {
+	return &mysqlAuditCheckDataSource{}
+}
+
+// mysqlAuditCheckDataSource is the data source implementation.
+type mysqlAuditCheckDataSource struct {
+	providerData *providerData
+}
+
+// mysqlAuditCheckDataSourceModel maps the data source schema data.
+type mysqlAuditCheckDataSourceModel struct {
+	mysqlConnectionModel
+	UserName          types.String `tfsdk:"user_name"`
+	UserHost          types.String `tfsdk:"user_host"`
+	RequiredGrants    types.List   `tfsdk:"required_grants"`
+	PluginLoaded      types.Bool   `tfsdk:"plugin_loaded"`
+	PluginStatus      types.String `tfsdk:"plugin_status"`
+	AuditVariables    types.Map    `tfsdk:"audit_variables"`
+	UserExists        types.Bool   `tfsdk:"user_exists"`
+	Grants            types.List   `tfsdk:"grants"`
+	MissingGrants     types.List   `tfsdk:"missing_grants"`
+	HasRequiredGrants types.Bool   `tfsdk:"has_required_grants"`
+}
+
+// mysqlAuditCheck is the outcome of the audit checks run against a MySQL or MariaDB server
+type mysqlAuditCheck struct {
+	// PluginStatus is empty when the audit plugin is not installed
+	PluginStatus   string
+	AuditVariables map[string]string
+	UserExists     bool
+	Grants         []string
+}
+
+// Metadata returns the data source type name.
+func (d *mysqlAuditCheckDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_mysql_audit_check"
+}
+
+// Schema defines the schema for the data source.
+func (d *mysqlAuditCheckDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Connects to MySQL or MariaDB and reports whether the audit plugin is loaded, its server_audit variables and the grants of a user.",
+		Attributes:  mysqlConnectionDataSourceAttributes(),
+	}
+
+	resp.Schema.Attributes["user_name"] = schema.StringAttribute{
+		Description: "User whose existence and grants are checked, for example the Guardium service account.",
+		Optional:    true,
+	}
+	resp.Schema.Attributes["user_host"] = schema.StringAttribute{
+		Description: "Host part of the checked account. Defaults to %.",
+		Optional:    true,
+	}
+	resp.Schema.Attributes["required_grants"] = schema.ListAttribute{
+		Description: "Global privileges (granted ON *.*) the user must hold, for example SELECT and PROCESS. ALL PRIVILEGES satisfies every privilege.",
+		ElementType: types.StringType,
+		Optional:    true,
+	}
+	resp.Schema.Attributes["plugin_loaded"] = schema.BoolAttribute{
+		Description: "Whether the SERVER_AUDIT plugin is listed as ACTIVE in INFORMATION_SCHEMA.PLUGINS.",
+		Computed:    true,
+	}
+	resp.Schema.Attributes["plugin_status"] = schema.StringAttribute{
+		Description: "PLUGIN_STATUS of the SERVER_AUDIT plugin. Null when the plugin is not installed.",
+		Computed:    true,
+	}
+	resp.Schema.Attributes["audit_variables"] = schema.MapAttribute{
+		Description: "Global server_audit% variables keyed by name.",
+		ElementType: types.StringType,
+		Computed:    true,
+	}
+	resp.Schema.Attributes["user_exists"] = schema.BoolAttribute{
+		Description: "Whether user_name@user_host exists. Null when user_name is not set.",
+		Computed:    true,
+	}
+	resp.Schema.Attributes["grants"] = schema.ListAttribute{
+		Description: "GRANT statements returned by SHOW GRANTS for the user. Null when the user does not exist.",
+		ElementType: types.StringType,
+		Computed:    true,
+	}
+	resp.Schema.Attributes["missing_grants"] = schema.ListAttribute{
+		Description: "Entries of required_grants the user does not hold globally. Null when user_name is not set.",
+		ElementType: types.StringType,
+		Computed:    true,
+	}
+	resp.Schema.Attributes["has_required_grants"] = schema.BoolAttribute{
+		Description: "Whether the user exists and holds every required grant. Null when user_name is not set.",
+		Computed:    true,
+	}
+}
+
+// Configure keeps the provider data.
+func (d *mysqlAuditCheckDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	tflog.Info(ctx, "Configuring MySQL audit check data source")
+
+	// If provider is not configured, return
+	if req.ProviderData == nil {
+		return
+	}
+
+	pd := providerDataFrom(req.ProviderData, &resp.Diagnostics)
+	if pd == nil {
+		return
+	}
+
+	d.providerData = pd
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *mysqlAuditCheckDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	// Get current state
+	var state mysqlAuditCheckDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var required []string
+	if !state.RequiredGrants.IsNull() {
+		resp.Diagnostics.Append(state.RequiredGrants.ElementsAs(ctx, &required, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	if state.UserHost.IsNull() || state.UserHost.ValueString() == "" {
+		state.UserHost = types.StringValue(defaultMySQLUserHost)
+	}
+
+	// Connect to MySQL
+	db, err := state.connect(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Connect to MySQL",
+			fmt.Sprintf("Error connecting to MySQL: %s", err),
+		)
+		return
+	}
+	defer db.Close()
+
+	check, err := checkMySQLAudit(ctx, db, state.UserName.ValueString(), state.UserHost.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to Query MySQL", err.Error())
+		return
+	}
+
+	// Set state
+	state.PluginLoaded = types.BoolValue(check.PluginStatus == "ACTIVE")
+	state.PluginStatus = types.StringNull()
+	if check.PluginStatus != "" {
+		state.PluginStatus = types.StringValue(check.PluginStatus)
+	}
+
+	variables, diags := types.MapValueFrom(ctx, types.StringType, check.AuditVariables)
+	resp.Diagnostics.Append(diags...)
+	state.AuditVariables = variables
+
+	state.UserExists = types.BoolNull()
+	state.Grants = types.ListNull(types.StringType)
+	state.MissingGrants = types.ListNull(types.StringType)
+	state.HasRequiredGrants = types.BoolNull()
+
+	if state.UserName.ValueString() != "" {
+		state.UserExists = types.BoolValue(check.UserExists)
+		missing := required
+		if check.UserExists {
+			state.Grants = stringListValue(ctx, check.Grants, &resp.Diagnostics)
+			missing = missingMySQLGrants(required, mysqlGlobalPrivileges(check.Grants))
+		}
+		state.MissingGrants = stringListValue(ctx, missing, &resp.Diagnostics)
+		state.HasRequiredGrants = types.BoolValue(check.UserExists && len(missing) == 0)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Log the result
+	tflog.Info(ctx, fmt.Sprintf("MySQL audit plugin loaded: %t", check.PluginStatus == "ACTIVE"))
+
+	// Save updated state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// checkMySQLAudit reads the audit plugin status and variables and, when userName is set, the grants of the user
+func checkMySQLAudit(ctx context.Context, db *sql.DB, userName, userHost string) (mysqlAuditCheck, error) {
+	check := mysqlAuditCheck{AuditVariables: map[string]string{}}
+
+	// No rows means the plugin is not installed
+	err := db.QueryRowContext(ctx, "SELECT PLUGIN_STATUS FROM INFORMATION_SCHEMA.PLUGINS WHERE PLUGIN_NAME = ?", mysqlAuditPluginName).Scan(&check.PluginStatus)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return check, fmt.Errorf("error reading audit plugin status: %w", err)
+	}
+
+	rows, err := db.QueryContext(ctx, "SHOW GLOBAL VARIABLES LIKE 'server_audit%'")
+	if err != nil {
+		return check, fmt.Errorf("error reading audit variables: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var name, value string
+		if err := rows.Scan(&name, &value); err != nil {
+			return check, fmt.Errorf("error reading audit variables: %w", err)
+		}
+		check.AuditVariables[name] = value
+	}
+	if err := rows.Err(); err != nil {
+		return check, fmt.Errorf("error reading audit variables: %w", err)
+	}
+
+	if userName == "" {
+		return check, nil
+	}
+
+	var count int
+	if err := db.QueryRowContext(ctx, "SELECT COUNT(*) FROM mysql.user WHERE User = ? AND Host = ?", userName, userHost).Scan(&count); err != nil {
+		return check, fmt.Errorf("error checking user %s@%s: %w", userName, userHost, err)
+	}
+	check.UserExists = count > 0
+	if !check.UserExists {
+		return check, nil
+	}
+
+	grantRows, err := db.QueryContext(ctx, "SHOW GRANTS FOR ?@?", userName, userHost)
+	if err != nil {
+		return check, fmt.Errorf("error reading grants of %s@%s: %w", userName, userHost, err)
+	}
+	defer grantRows.Close()
+	for grantRows.Next() {
+		var grant string
+		if err := grantRows.Scan(&grant); err != nil {
+			return check, fmt.Errorf("error reading grants of %s@%s: %w", userName, userHost, err)
+		}
+		check.Grants = append(check.Grants, grant)
+	}
+	if err := grantRows.Err(); err != nil {
+		return check, fmt.Errorf("error reading grants of %s@%s: %w", userName, userHost, err)
+	}
+	return check, nil
+}
+
+// mysqlGlobalPrivileges returns the privileges granted ON *.* by the given GRANT statements, in upper case.
+// Role grants and grants on databases or tables are ignored.
+func mysqlGlobalPrivileges(grants []string) []string {
+	var privileges []string
+	for _, grant := range grants {
+		rest, ok := strings.CutPrefix(grant, "GRANT ")
+		if !ok {
+			continue
+		}
+		list, target, ok := strings.Cut(rest, " ON ")
+		if !ok || !strings.HasPrefix(target, "*.* TO ") {
+			continue
+		}
+		for _, privilege := range strings.Split(list, ",") {
+			privileges = append(privileges, strings.ToUpper(strings.TrimSpace(privilege)))
+		}
+	}
+	sort.Strings(privileges)
+	return privileges
+}
+
+// missingMySQLGrants returns the required privileges that are not granted, ALL PRIVILEGES satisfies every privilege
+func missingMySQLGrants(required, granted []string) []string {
+	held := make(map[string]bool, len(granted))
+	for _, privilege := range granted {
+		held[privilege] = true
+	}
+	if held[mysqlAllPrivileges] {
+		return nil
+	}
+
+	var missing []string
+	for _, privilege := range required {
+		if !held[strings.ToUpper(strings.TrimSpace(privilege))] {
+			missing = append(missing, privilege)
+		}
+	}
+	return missing
+}
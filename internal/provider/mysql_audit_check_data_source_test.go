@@ -0,0 +1,307 @@
+// Copyright (c) IBM Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"io"
+	"maps"
+	"net"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// fakeMySQLResult is a text protocol result set returned by the fake server
+type fakeMySQLResult struct {
+	columns []string
+	rows    [][]string
+}
+
+// startFakeMySQLServer serves the MySQL wire protocol on a local port, accepting any credentials and
+// answering COM_QUERY with the result registered for the exact query text
+func startFakeMySQLServer(t *testing.T, results map[string]fakeMySQLResult) mysqlConnectionModel {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("unable to listen: %s", err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go serveFakeMySQL(conn, results)
+		}
+	}()
+
+	host, port, _ := net.SplitHostPort(listener.Addr().String())
+	return mysqlConnectionModel{
+		Host:     types.StringValue(host),
+		Port:     types.StringValue(port),
+		Username: types.StringValue("admin"),
+		Password: types.StringValue("secret"),
+		// The fake server does not offer TLS, so this also covers the plaintext fallback
+		TLSMode: types.StringValue(mysqlTLSPreferred),
+	}
+}
+
+// Capability flags offered by the fake server
+const (
+	fakeMySQLClientLongPassword     = 0x00000001
+	fakeMySQLClientProtocol41       = 0x00000200
+	fakeMySQLClientTransactions     = 0x00002000
+	fakeMySQLClientSecureConnection = 0x00008000
+	fakeMySQLClientPluginAuth       = 0x00080000
+)
+
+func serveFakeMySQL(conn net.Conn, results map[string]fakeMySQLResult) {
+	defer conn.Close()
+
+	capabilities := uint32(fakeMySQLClientLongPassword | fakeMySQLClientProtocol41 | fakeMySQLClientTransactions |
+		fakeMySQLClientSecureConnection | fakeMySQLClientPluginAuth)
+
+	handshake := []byte{10}
+	handshake = append(handshake, "8.0.35-fake\x00"...)
+	handshake = binary.LittleEndian.AppendUint32(handshake, 1)
+	handshake = append(handshake, "abcdefgh\x00"...)
+	handshake = binary.LittleEndian.AppendUint16(handshake, uint16(capabilities))
+	handshake = append(handshake, 33)
+	handshake = binary.LittleEndian.AppendUint16(handshake, 2)
+	handshake = binary.LittleEndian.AppendUint16(handshake, uint16(capabilities>>16))
+	handshake = append(handshake, 21)
+	handshake = append(handshake, make([]byte, 10)...)
+	handshake = append(handshake, "ijklmnopqrst\x00"...)
+	handshake = append(handshake, "mysql_native_password\x00"...)
+	if writeFakeMySQLPacket(conn, 0, handshake) != nil {
+		return
+	}
+
+	// Accept any handshake response
+	if _, _, err := readFakeMySQLPacket(conn); err != nil {
+		return
+	}
+	if writeFakeMySQLPacket(conn, 2, fakeMySQLOK()) != nil {
+		return
+	}
+
+	for {
+		_, payload, err := readFakeMySQLPacket(conn)
+		if err != nil || len(payload) == 0 {
+			return
+		}
+
+		switch payload[0] {
+		case 0x01: // COM_QUIT
+			return
+		case 0x0e: // COM_PING
+			err = writeFakeMySQLPacket(conn, 1, fakeMySQLOK())
+		case 0x03: // COM_QUERY
+			query := string(payload[1:])
+			result, ok := results[query]
+			switch {
+			case ok:
+				err = writeFakeMySQLResult(conn, result)
+			case strings.HasPrefix(query, "SET "):
+				err = writeFakeMySQLPacket(conn, 1, fakeMySQLOK())
+			default:
+				err = writeFakeMySQLPacket(conn, 1, fakeMySQLError("unexpected query: "+query))
+			}
+		default:
+			err = writeFakeMySQLPacket(conn, 1, fakeMySQLError("unsupported command"))
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+func readFakeMySQLPacket(conn net.Conn) (byte, []byte, error) {
+	var header [4]byte
+	if _, err := io.ReadFull(conn, header[:]); err != nil {
+		return 0, nil, err
+	}
+	length := int(header[0]) | int(header[1])<<8 | int(header[2])<<16
+	payload := make([]byte, length)
+	if _, err := io.ReadFull(conn, payload); err != nil {
+		return 0, nil, err
+	}
+	return header[3], payload, nil
+}
+
+func writeFakeMySQLPacket(conn net.Conn, seq byte, payload []byte) error {
+	if len(payload) >= 1<<24 {
+		return errors.New("packet too large")
+	}
+	header := []byte{byte(len(payload)), byte(len(payload) >> 8), byte(len(payload) >> 16), seq}
+	_, err := conn.Write(append(header, payload...))
+	return err
+}
+
+func fakeMySQLOK() []byte {
+	return []byte{0x00, 0x00, 0x00, 0x02, 0x00, 0x00, 0x00}
+}
+
+func fakeMySQLEOF() []byte {
+	return []byte{0xfe, 0x00, 0x00, 0x02, 0x00}
+}
+
+func fakeMySQLError(message string) []byte {
+	payload := []byte{0xff}
+	payload = binary.LittleEndian.AppendUint16(payload, 1064)
+	payload = append(payload, "#42000"...)
+	return append(payload, message...)
+}
+
+// appendFakeMySQLString appends a length encoded string, the fake results only hold short values
+func appendFakeMySQLString(b []byte, s string) []byte {
+	return append(append(b, byte(len(s))), s...)
+}
+
+func writeFakeMySQLResult(conn net.Conn, result fakeMySQLResult) error {
+	seq := byte(1)
+	write := func(payload []byte) error {
+		err := writeFakeMySQLPacket(conn, seq, payload)
+		seq++
+		return err
+	}
+
+	if err := write([]byte{byte(len(result.columns))}); err != nil {
+		return err
+	}
+	for _, column := range result.columns {
+		def := appendFakeMySQLString(nil, "def")
+		def = appendFakeMySQLString(def, "")
+		def = appendFakeMySQLString(def, "")
+		def = appendFakeMySQLString(def, "")
+		def = appendFakeMySQLString(def, column)
+		def = appendFakeMySQLString(def, column)
+		def = append(def, 0x0c)
+		def = binary.LittleEndian.AppendUint16(def, 33)
+		def = binary.LittleEndian.AppendUint32(def, 1024)
+		def = append(def, 0xfd, 0x00, 0x00, 0x00, 0x00, 0x00)
+		if err := write(def); err != nil {
+			return err
+		}
+	}
+	if err := write(fakeMySQLEOF()); err != nil {
+		return err
+	}
+	for _, row := range result.rows {
+		var values []byte
+		for _, value := range row {
+			values = appendFakeMySQLString(values, value)
+		}
+		if err := write(values); err != nil {
+			return err
+		}
+	}
+	return write(fakeMySQLEOF())
+}
+
+const (
+	fakeMySQLPluginQuery    = "SELECT PLUGIN_STATUS FROM INFORMATION_SCHEMA.PLUGINS WHERE PLUGIN_NAME = 'SERVER_AUDIT'"
+	fakeMySQLVariablesQuery = "SHOW GLOBAL VARIABLES LIKE 'server_audit%'"
+	fakeMySQLUserQuery      = "SELECT COUNT(*) FROM mysql.user WHERE User = 'guardium' AND Host = '%'"
+	fakeMySQLGrantsQuery    = "SHOW GRANTS FOR 'guardium'@'%'"
+)
+
+func TestCheckMySQLAudit(t *testing.T) {
+	m := startFakeMySQLServer(t, map[string]fakeMySQLResult{
+		fakeMySQLPluginQuery: {columns: []string{"PLUGIN_STATUS"}, rows: [][]string{{"ACTIVE"}}},
+		fakeMySQLVariablesQuery: {
+			columns: []string{"Variable_name", "Value"},
+			rows: [][]string{
+				{"server_audit_events", "CONNECT,QUERY_DDL"},
+				{"server_audit_logging", "ON"},
+			},
+		},
+		fakeMySQLUserQuery: {columns: []string{"COUNT(*)"}, rows: [][]string{{"1"}}},
+		fakeMySQLGrantsQuery: {
+			columns: []string{"Grants for guardium@%"},
+			rows:    [][]string{{"GRANT SELECT, PROCESS ON *.* TO `guardium`@`%`"}},
+		},
+	})
+
+	ctx := context.Background()
+	db, err := m.connect(ctx)
+	if err != nil {
+		t.Fatalf("unable to connect: %s", err)
+	}
+	defer db.Close()
+
+	check, err := checkMySQLAudit(ctx, db, "guardium", "%")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if check.PluginStatus != "ACTIVE" {
+		t.Errorf("expected an active plugin, got %q", check.PluginStatus)
+	}
+	if want := map[string]string{"server_audit_events": "CONNECT,QUERY_DDL", "server_audit_logging": "ON"}; !maps.Equal(check.AuditVariables, want) {
+		t.Errorf("expected variables %v, got %v", want, check.AuditVariables)
+	}
+	if !check.UserExists {
+		t.Error("expected the user to exist")
+	}
+	if len(check.Grants) != 1 {
+		t.Errorf("expected one grant, got %v", check.Grants)
+	}
+}
+
+func TestCheckMySQLAuditWithoutPlugin(t *testing.T) {
+	m := startFakeMySQLServer(t, map[string]fakeMySQLResult{
+		fakeMySQLPluginQuery:    {columns: []string{"PLUGIN_STATUS"}},
+		fakeMySQLVariablesQuery: {columns: []string{"Variable_name", "Value"}},
+		fakeMySQLUserQuery:      {columns: []string{"COUNT(*)"}, rows: [][]string{{"0"}}},
+	})
+
+	ctx := context.Background()
+	db, err := m.connect(ctx)
+	if err != nil {
+		t.Fatalf("unable to connect: %s", err)
+	}
+	defer db.Close()
+
+	check, err := checkMySQLAudit(ctx, db, "guardium", "%")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if check.PluginStatus != "" || len(check.AuditVariables) != 0 {
+		t.Errorf("expected no plugin, got %+v", check)
+	}
+	if check.UserExists || check.Grants != nil {
+		t.Errorf("expected a missing user, got %+v", check)
+	}
+}
+
+func TestMySQLGlobalPrivileges(t *testing.T) {
+	grants := []string{
+		"GRANT SELECT, PROCESS, REPLICATION CLIENT ON *.* TO `guardium`@`%`",
+		"GRANT INSERT ON `orders`.* TO `guardium`@`%`",
+		"GRANT `audit_reader`@`%` TO `guardium`@`%`",
+		"GRANT AUDIT_ADMIN ON *.* TO `guardium`@`%`",
+	}
+	want := []string{"AUDIT_ADMIN", "PROCESS", "REPLICATION CLIENT", "SELECT"}
+	if got := mysqlGlobalPrivileges(grants); !slices.Equal(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
+
+func TestMissingMySQLGrants(t *testing.T) {
+	granted := []string{"PROCESS", "SELECT"}
+	if got := missingMySQLGrants([]string{"select", "PROCESS", "SHOW DATABASES"}, granted); !slices.Equal(got, []string{"SHOW DATABASES"}) {
+		t.Errorf("expected SHOW DATABASES to be missing, got %v", got)
+	}
+	if got := missingMySQLGrants([]string{"SUPER"}, []string{mysqlAllPrivileges}); got != nil {
+		t.Errorf("expected ALL PRIVILEGES to satisfy every grant, got %v", got)
+	}
+}
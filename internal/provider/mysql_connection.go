@@ -0,0 +1,166 @@
+// Copyright (c) IBM Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	dsschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const (
+	defaultMySQLPort    = "3306"
+	defaultMySQLTLSMode = mysqlTLSPreferred
+)
+
+// TLS modes of the MySQL connection
+const (
+	// mysqlTLSDisable never uses TLS
+	mysqlTLSDisable = "disable"
+	// mysqlTLSPreferred uses TLS without verifying the certificate when the server supports it
+	mysqlTLSPreferred = "preferred"
+	// mysqlTLSRequired requires TLS without verifying the certificate
+	mysqlTLSRequired = "required"
+	// mysqlTLSVerifyFull requires TLS and verifies the certificate chain and the host name
+	mysqlTLSVerifyFull = "verify-full"
+)
+
+// mysqlConnectionModel maps the connection attributes of the MySQL and MariaDB data sources.
+// It is embedded by value in their models.
+type mysqlConnectionModel struct {
+	Host           types.String `tfsdk:"host"`
+	Port           types.String `tfsdk:"port"`
+	Username       types.String `tfsdk:"username"`
+	Password       types.String `tfsdk:"password"`
+	TLSMode        types.String `tfsdk:"tls_mode"`
+	TLSCACert      types.String `tfsdk:"tls_ca_cert"`
+	ConnectTimeout types.Int64  `tfsdk:"connect_timeout"`
+}
+
+// mysqlConnectionDataSourceAttributes returns the connection attributes for MySQL data sources
+func mysqlConnectionDataSourceAttributes() map[string]dsschema.Attribute {
+	return map[string]dsschema.Attribute{
+		"host": dsschema.StringAttribute{
+			Description: "MySQL or MariaDB server hostname or IP address.",
+			Required:    true,
+		},
+		"port": dsschema.StringAttribute{
+			Description: "MySQL or MariaDB server port.",
+			Optional:    true,
+			Computed:    true,
+		},
+		"username": dsschema.StringAttribute{
+			Description: "MySQL or MariaDB username.",
+			Required:    true,
+		},
+		"password": dsschema.StringAttribute{
+			Description: "MySQL or MariaDB password.",
+			Optional:    true,
+			Sensitive:   true,
+		},
+		"tls_mode": dsschema.StringAttribute{
+			Description: "TLS mode (disable, preferred, required, verify-full). preferred and required do not verify the server certificate.",
+			Optional:    true,
+			Computed:    true,
+		},
+		"tls_ca_cert": dsschema.StringAttribute{
+			Description: "Path to the CA bundle used to verify the server certificate, for example the RDS global bundle with tls_mode verify-full.",
+			Optional:    true,
+		},
+		"connect_timeout": dsschema.Int64Attribute{
+			Description: "Maximum time to wait for the connection, in seconds.",
+			Optional:    true,
+		},
+	}
+}
+
+// setDefaults fills in the port and TLS mode when they are not configured
+func (m *mysqlConnectionModel) setDefaults() {
+	if m.Port.IsNull() || m.Port.IsUnknown() || m.Port.ValueString() == "" {
+		m.Port = types.StringValue(defaultMySQLPort)
+	}
+
+	if m.TLSMode.IsNull() || m.TLSMode.IsUnknown() || m.TLSMode.ValueString() == "" {
+		m.TLSMode = types.StringValue(defaultMySQLTLSMode)
+	}
+}
+
+// config builds the driver configuration. Placeholders are interpolated on the client so that
+// statements such as SHOW GRANTS FOR ?@? can take arguments.
+func (m *mysqlConnectionModel) config() (*mysql.Config, error) {
+	m.setDefaults()
+
+	if m.Host.ValueString() == "" {
+		return nil, errors.New("host is required")
+	}
+	if m.Username.ValueString() == "" {
+		return nil, errors.New("username is required")
+	}
+
+	cfg := mysql.NewConfig()
+	cfg.Net = "tcp"
+	cfg.Addr = net.JoinHostPort(m.Host.ValueString(), m.Port.ValueString())
+	cfg.User = m.Username.ValueString()
+	cfg.Passwd = m.Password.ValueString()
+	cfg.InterpolateParams = true
+	if !m.ConnectTimeout.IsNull() {
+		cfg.Timeout = time.Duration(m.ConnectTimeout.ValueInt64()) * time.Second
+	}
+
+	switch mode := m.TLSMode.ValueString(); mode {
+	case mysqlTLSDisable:
+	case mysqlTLSPreferred:
+		cfg.TLS = &tls.Config{InsecureSkipVerify: true}
+		cfg.AllowFallbackToPlaintext = true
+	case mysqlTLSRequired:
+		cfg.TLS = &tls.Config{InsecureSkipVerify: true}
+	case mysqlTLSVerifyFull:
+		cfg.TLS = &tls.Config{ServerName: m.Host.ValueString()}
+		if path := m.TLSCACert.ValueString(); path != "" {
+			pem, err := os.ReadFile(path)
+			if err != nil {
+				return nil, fmt.Errorf("error reading tls_ca_cert: %w", err)
+			}
+			pool := x509.NewCertPool()
+			if !pool.AppendCertsFromPEM(pem) {
+				return nil, fmt.Errorf("no certificate found in tls_ca_cert %s", path)
+			}
+			cfg.TLS.RootCAs = pool
+		}
+	default:
+		return nil, fmt.Errorf("tls_mode must be %s, %s, %s or %s, got %q", mysqlTLSDisable, mysqlTLSPreferred, mysqlTLSRequired, mysqlTLSVerifyFull, mode)
+	}
+	return cfg, nil
+}
+
+// connect opens a connection to the configured MySQL or MariaDB server and checks that it is reachable
+func (m *mysqlConnectionModel) connect(ctx context.Context) (*sql.DB, error) {
+	cfg, err := m.config()
+	if err != nil {
+		return nil, err
+	}
+
+	connector, err := mysql.NewConnector(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	db := sql.OpenDB(connector)
+	db.SetMaxOpenConns(1)
+	if err := db.PingContext(ctx); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return db, nil
+}
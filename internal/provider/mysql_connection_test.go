@@ -0,0 +1,92 @@
+// Copyright (c) IBM Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestMySQLConfigDefaults(t *testing.T) {
+	m := mysqlConnectionModel{
+		Host:           types.StringValue("orders.abc.us-east-1.rds.amazonaws.com"),
+		Username:       types.StringValue("guardium"),
+		Password:       types.StringValue("p@ss"),
+		ConnectTimeout: types.Int64Value(5),
+	}
+
+	cfg, err := m.config()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if cfg.Addr != "orders.abc.us-east-1.rds.amazonaws.com:3306" {
+		t.Errorf("unexpected address %s", cfg.Addr)
+	}
+	if !cfg.InterpolateParams {
+		t.Error("expected placeholders to be interpolated")
+	}
+	if cfg.Timeout != 5*time.Second {
+		t.Errorf("expected a 5s timeout, got %s", cfg.Timeout)
+	}
+	if m.TLSMode.ValueString() != mysqlTLSPreferred || cfg.TLS == nil || !cfg.AllowFallbackToPlaintext {
+		t.Errorf("expected preferred TLS with plaintext fallback, got %s %+v", m.TLSMode, cfg)
+	}
+}
+
+func TestMySQLConfigTLSModes(t *testing.T) {
+	base := mysqlConnectionModel{
+		Host:     types.StringValue("db.example.com"),
+		Username: types.StringValue("guardium"),
+	}
+
+	m := base
+	m.TLSMode = types.StringValue(mysqlTLSDisable)
+	if cfg, err := m.config(); err != nil || cfg.TLS != nil {
+		t.Errorf("expected no TLS, got %v (%v)", cfg, err)
+	}
+
+	m = base
+	m.TLSMode = types.StringValue(mysqlTLSRequired)
+	if cfg, err := m.config(); err != nil || cfg.TLS == nil || cfg.AllowFallbackToPlaintext {
+		t.Errorf("expected required TLS, got %v (%v)", cfg, err)
+	}
+
+	m = base
+	m.TLSMode = types.StringValue(mysqlTLSVerifyFull)
+	cfg, err := m.config()
+	if err != nil || cfg.TLS == nil || cfg.TLS.InsecureSkipVerify || cfg.TLS.ServerName != "db.example.com" {
+		t.Errorf("expected verified TLS, got %v (%v)", cfg, err)
+	}
+
+	m.TLSCACert = types.StringValue(filepath.Join(t.TempDir(), "missing.pem"))
+	if _, err := m.config(); err == nil {
+		t.Error("expected an error for a missing CA bundle")
+	}
+
+	empty := filepath.Join(t.TempDir(), "empty.pem")
+	if err := os.WriteFile(empty, []byte("not a certificate"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	m.TLSCACert = types.StringValue(empty)
+	if _, err := m.config(); err == nil {
+		t.Error("expected an error for a CA bundle without certificates")
+	}
+
+	m = base
+	m.TLSMode = types.StringValue("verify-ca")
+	if _, err := m.config(); err == nil {
+		t.Error("expected an error for an unknown TLS mode")
+	}
+}
+
+func TestMySQLConfigRequiresHost(t *testing.T) {
+	m := mysqlConnectionModel{Username: types.StringValue("guardium")}
+	if _, err := m.config(); err == nil {
+		t.Error("expected an error without host")
+	}
+}
@@ -9,9 +9,9 @@ import (
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
-	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/neptune"
 	"github.com/aws/aws-sdk-go-v2/service/neptune/types"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -33,7 +33,7 @@ func NewNeptuneModifyResource() resource.Resource {
 
 // NeptuneModifyResource defines the resource implementation.
 type NeptuneModifyResource struct {
-	client *neptune.Client
+	providerData *providerData
 }
 
 // NeptuneModifyResourceModel describes the resource data model.
@@ -45,6 +45,7 @@ type NeptuneModifyResourceModel struct {
 	ApplyImmediately          frameworktypes.Bool   `tfsdk:"apply_immediately"`
 	LastModifiedTime          frameworktypes.String `tfsdk:"last_modified_time"`
 	ID                        frameworktypes.String `tfsdk:"id"`
+	Timeouts                  timeouts.Value        `tfsdk:"timeouts"`
 }
 
 func (r *NeptuneModifyResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -99,6 +100,12 @@ func (r *NeptuneModifyResource) Schema(ctx context.Context, req resource.SchemaR
 				},
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Update: true,
+			}),
+		},
 	}
 }
 
@@ -110,14 +117,13 @@ func (r *NeptuneModifyResource) Configure(ctx context.Context, req resource.Conf
 		return
 	}
 
-	// Create AWS config and Neptune client
-	awsCfg, err := config.LoadDefaultConfig(ctx)
-	if err != nil {
-		resp.Diagnostics.AddError("Unable to load AWS SDK config", fmt.Sprintf("Unable to load AWS SDK config: %s", err))
+	// Keep the provider data, Neptune clients are built per region on first use
+	pd := providerDataFrom(req.ProviderData, &resp.Diagnostics)
+	if pd == nil {
 		return
 	}
 
-	r.client = neptune.NewFromConfig(awsCfg)
+	r.providerData = pd
 }
 
 func (r *NeptuneModifyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -129,21 +135,22 @@ func (r *NeptuneModifyResource) Create(ctx context.Context, req resource.CreateR
 		return
 	}
 
-	// If region is specified, update the AWS config
-	var client *neptune.Client
-	if !data.Region.IsNull() {
-		tflog.Debug(ctx, "configuring client with region")
-		awsCfg, err := config.LoadDefaultConfig(ctx, config.WithRegion(data.Region.ValueString()))
-		if err != nil {
-			resp.Diagnostics.AddError("Unable to load AWS SDK config", fmt.Sprintf("Unable to load AWS SDK config with region %s: %s", data.Region.ValueString(), err))
-			return
-		}
-		client = neptune.NewFromConfig(awsCfg)
-	} else {
-		tflog.Debug(ctx, "using default client")
-		client = r.client
+	if !r.providerData.configured(&resp.Diagnostics) {
+		return
 	}
 
+	timeout, diags := data.Timeouts.Create(ctx, defaultWaitTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	// Get the cached client for the requested region
+	client := r.providerData.neptuneClient(data.Region.ValueString())
+
 	// Prepare modify input
 	input := &neptune.ModifyDBClusterInput{
 		DBClusterIdentifier: aws.String(data.ClusterIdentifier.ValueString()),
@@ -184,31 +191,11 @@ func (r *NeptuneModifyResource) Create(ctx context.Context, req resource.CreateR
 		return
 	}
 
-	// Wait for the cluster to become available again using polling
+	// Wait for the cluster to become available again
 	tflog.Info(ctx, "Waiting for Neptune cluster to become available after modification")
-	maxAttempts := 60 // 30 minutes with 30 second intervals
-	for i := 0; i < maxAttempts; i++ {
-		describeInput := &neptune.DescribeDBClustersInput{
-			DBClusterIdentifier: aws.String(data.ClusterIdentifier.ValueString()),
-		}
-
-		result, err := client.DescribeDBClusters(ctx, describeInput)
-		if err != nil {
-			resp.Diagnostics.AddError("Error describing Neptune cluster", fmt.Sprintf("Could not describe Neptune cluster: %s", err))
-			return
-		}
-
-		if len(result.DBClusters) > 0 && *result.DBClusters[0].Status == "available" {
-			tflog.Info(ctx, "Neptune cluster is now available")
-			break
-		}
-
-		if i == maxAttempts-1 {
-			resp.Diagnostics.AddError("Timeout waiting for Neptune cluster", "Neptune cluster did not become available within 30 minutes")
-			return
-		}
-
-		time.Sleep(30 * time.Second)
+	if err := waitForNeptuneClusterAvailable(ctx, client, data.ClusterIdentifier.ValueString(), timeout); err != nil {
+		resp.Diagnostics.AddError("Error waiting for Neptune cluster to become available", fmt.Sprintf("Could not confirm Neptune cluster availability: %s", err))
+		return
 	}
 
 	// Set computed values
@@ -229,21 +216,13 @@ func (r *NeptuneModifyResource) Read(ctx context.Context, req resource.ReadReque
 		return
 	}
 
-	// If region is specified, update the AWS config
-	var client *neptune.Client
-	if !data.Region.IsNull() {
-		tflog.Debug(ctx, "configuring client with region")
-		awsCfg, err := config.LoadDefaultConfig(ctx, config.WithRegion(data.Region.ValueString()))
-		if err != nil {
-			resp.Diagnostics.AddError("Unable to load AWS SDK config", fmt.Sprintf("Unable to load AWS SDK config with region %s: %s", data.Region.ValueString(), err))
-			return
-		}
-		client = neptune.NewFromConfig(awsCfg)
-	} else {
-		tflog.Debug(ctx, "using default client")
-		client = r.client
+	if !r.providerData.configured(&resp.Diagnostics) {
+		return
 	}
 
+	// Get the cached client for the requested region
+	client := r.providerData.neptuneClient(data.Region.ValueString())
+
 	// Check if the Neptune cluster exists
 	input := &neptune.DescribeDBClustersInput{
 		DBClusterIdentifier: aws.String(data.ClusterIdentifier.ValueString()),
@@ -268,21 +247,22 @@ func (r *NeptuneModifyResource) Update(ctx context.Context, req resource.UpdateR
 		return
 	}
 
-	// If region is specified, update the AWS config
-	var client *neptune.Client
-	if !data.Region.IsNull() {
-		tflog.Debug(ctx, "configuring client with region")
-		awsCfg, err := config.LoadDefaultConfig(ctx, config.WithRegion(data.Region.ValueString()))
-		if err != nil {
-			resp.Diagnostics.AddError("Unable to load AWS SDK config", fmt.Sprintf("Unable to load AWS SDK config with region %s: %s", data.Region.ValueString(), err))
-			return
-		}
-		client = neptune.NewFromConfig(awsCfg)
-	} else {
-		tflog.Debug(ctx, "using default client")
-		client = r.client
+	if !r.providerData.configured(&resp.Diagnostics) {
+		return
+	}
+
+	timeout, diags := data.Timeouts.Update(ctx, defaultWaitTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	// Get the cached client for the requested region
+	client := r.providerData.neptuneClient(data.Region.ValueString())
+
 	// Prepare modify input
 	input := &neptune.ModifyDBClusterInput{
 		DBClusterIdentifier: aws.String(data.ClusterIdentifier.ValueString()),
@@ -323,31 +303,11 @@ func (r *NeptuneModifyResource) Update(ctx context.Context, req resource.UpdateR
 		return
 	}
 
-	// Wait for the cluster to become available again using polling
+	// Wait for the cluster to become available again
 	tflog.Debug(ctx, "Waiting for Neptune cluster to become available after modification")
-	maxAttempts := 60 // 30 minutes with 30 second intervals
-	for i := 0; i < maxAttempts; i++ {
-		describeInput := &neptune.DescribeDBClustersInput{
-			DBClusterIdentifier: aws.String(data.ClusterIdentifier.ValueString()),
-		}
-
-		result, err := client.DescribeDBClusters(ctx, describeInput)
-		if err != nil {
-			resp.Diagnostics.AddError("Error describing Neptune cluster", fmt.Sprintf("Could not describe Neptune cluster: %s", err))
-			return
-		}
-
-		if len(result.DBClusters) > 0 && *result.DBClusters[0].Status == "available" {
-			tflog.Debug(ctx, "Neptune cluster is now available")
-			break
-		}
-
-		if i == maxAttempts-1 {
-			resp.Diagnostics.AddError("Timeout waiting for Neptune cluster", "Neptune cluster did not become available within 30 minutes")
-			return
-		}
-
-		time.Sleep(30 * time.Second)
+	if err := waitForNeptuneClusterAvailable(ctx, client, data.ClusterIdentifier.ValueString(), timeout); err != nil {
+		resp.Diagnostics.AddError("Error waiting for Neptune cluster to become available", fmt.Sprintf("Could not confirm Neptune cluster availability: %s", err))
+		return
 	}
 
 	// Set computed values
@@ -366,3 +326,28 @@ func (r *NeptuneModifyResource) Delete(ctx context.Context, req resource.DeleteR
 func (r *NeptuneModifyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	resource.ImportStatePassthroughID(ctx, path.Root("cluster_identifier"), req, resp)
 }
+
+// waitForNeptuneClusterAvailable polls the Neptune cluster status until it is available
+func waitForNeptuneClusterAvailable(ctx context.Context, client *neptune.Client, clusterID string, timeout time.Duration) error {
+	poller := &statusPoller{
+		Resource: fmt.Sprintf("Neptune cluster %s", clusterID),
+		Target:   []string{"available"},
+		Failure:  dbFailureStatuses,
+		Timeout:  timeout,
+		Refresh: func(ctx context.Context) (string, error) {
+			result, err := client.DescribeDBClusters(ctx, &neptune.DescribeDBClustersInput{
+				DBClusterIdentifier: aws.String(clusterID),
+			})
+			if err != nil {
+				return "", err
+			}
+			if len(result.DBClusters) == 0 {
+				return "", fmt.Errorf("neptune cluster %s not found", clusterID)
+			}
+			return aws.ToString(result.DBClusters[0].Status), nil
+		},
+	}
+
+	_, err := poller.Wait(ctx)
+	return err
+}
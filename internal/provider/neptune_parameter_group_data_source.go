@@ -8,7 +8,6 @@ import (
 	"fmt"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
-	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/neptune"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
@@ -26,7 +25,7 @@ func NewNeptuneParameterGroupDataSource() datasource.DataSource {
 
 // NeptuneParameterGroupDataSource defines the data source implementation.
 type NeptuneParameterGroupDataSource struct {
-	client *neptune.Client
+	providerData *providerData
 }
 
 // NeptuneParameterGroupDataSourceModel describes the data source data model.
@@ -36,6 +35,8 @@ type NeptuneParameterGroupDataSourceModel struct {
 	ParameterGroup    types.String `tfsdk:"parameter_group"`
 	FamilyName        types.String `tfsdk:"family_name"`
 	Description       types.String `tfsdk:"description"`
+	Names             types.List   `tfsdk:"names"`
+	Parameters        types.List   `tfsdk:"parameters"`
 	ID                types.String `tfsdk:"id"`
 }
 
@@ -69,6 +70,8 @@ func (d *NeptuneParameterGroupDataSource) Schema(ctx context.Context, req dataso
 				MarkdownDescription: "Neptune cluster parameter group description",
 				Computed:            true,
 			},
+			"names":      parameterNamesAttribute("`neptune_enable_audit_log`"),
+			"parameters": parametersAttribute(),
 			"id": schema.StringAttribute{
 				Computed:            true,
 				MarkdownDescription: "Identifier of the data source",
@@ -85,14 +88,13 @@ func (d *NeptuneParameterGroupDataSource) Configure(ctx context.Context, req dat
 		return
 	}
 
-	// Create AWS config and Neptune client
-	awsCfg, err := config.LoadDefaultConfig(ctx)
-	if err != nil {
-		resp.Diagnostics.AddError("Unable to load AWS SDK config", fmt.Sprintf("Unable to load AWS SDK config: %s", err))
+	// Keep the provider data, Neptune clients are built per region on first use
+	pd := providerDataFrom(req.ProviderData, &resp.Diagnostics)
+	if pd == nil {
 		return
 	}
 
-	d.client = neptune.NewFromConfig(awsCfg)
+	d.providerData = pd
 }
 
 func (d *NeptuneParameterGroupDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -104,21 +106,13 @@ func (d *NeptuneParameterGroupDataSource) Read(ctx context.Context, req datasour
 		return
 	}
 
-	// If region is specified, update the AWS config
-	var client *neptune.Client
-	if !data.Region.IsNull() {
-		tflog.Debug(ctx, "configuring client with region")
-		awsCfg, err := config.LoadDefaultConfig(ctx, config.WithRegion(data.Region.ValueString()))
-		if err != nil {
-			resp.Diagnostics.AddError("Unable to load AWS SDK config", fmt.Sprintf("Unable to load AWS SDK config with region %s: %s", data.Region.ValueString(), err))
-			return
-		}
-		client = neptune.NewFromConfig(awsCfg)
-	} else {
-		tflog.Debug(ctx, "using default client")
-		client = d.client
+	if !d.providerData.configured(&resp.Diagnostics) {
+		return
 	}
 
+	// Get the cached client for the requested region
+	client := d.providerData.neptuneClient(data.Region.ValueString())
+
 	// Get Neptune DB cluster information
 	input := &neptune.DescribeDBClustersInput{DBClusterIdentifier: aws.String(data.ClusterIdentifier.ValueString())}
 
@@ -164,5 +158,21 @@ func (d *NeptuneParameterGroupDataSource) Read(ctx context.Context, req datasour
 	data.Description = types.StringValue(*pgResp.DBClusterParameterGroups[0].Description)
 	data.ID = types.StringValue(data.ClusterIdentifier.ValueString())
 
+	// Read the parameter values, restricted to names when set
+	names := parameterNames(ctx, data.Names, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	parameters, err := neptuneClusterParameterValues(ctx, client, data.ParameterGroup.ValueString(), names)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to describe DB cluster parameters", fmt.Sprintf("Error describing parameters of %s: %s", data.ParameterGroup.ValueString(), err))
+		return
+	}
+	data.Parameters = parametersListValue(ctx, parameters, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -9,12 +9,15 @@ import (
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
-	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/neptune"
+	neptunetypes "github.com/aws/aws-sdk-go-v2/service/neptune/types"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
@@ -30,15 +33,18 @@ func NewNeptuneRebootResource() resource.Resource {
 
 // NeptuneRebootResource defines the resource implementation.
 type NeptuneRebootResource struct {
-	client *neptune.Client
+	providerData *providerData
 }
 
 // NeptuneRebootResourceModel describes the resource data model.
 type NeptuneRebootResourceModel struct {
-	ClusterIdentifier types.String `tfsdk:"cluster_identifier"`
-	Region            types.String `tfsdk:"region"`
-	LastRebootTime    types.String `tfsdk:"last_reboot_time"`
-	ID                types.String `tfsdk:"id"`
+	ClusterIdentifier   types.String   `tfsdk:"cluster_identifier"`
+	Region              types.String   `tfsdk:"region"`
+	OnlyIfPendingReboot types.Bool     `tfsdk:"only_if_pending_reboot"`
+	Strategy            types.String   `tfsdk:"strategy"`
+	LastRebootTime      types.String   `tfsdk:"last_reboot_time"`
+	ID                  types.String   `tfsdk:"id"`
+	Timeouts            timeouts.Value `tfsdk:"timeouts"`
 }
 
 func (r *NeptuneRebootResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -62,6 +68,17 @@ func (r *NeptuneRebootResource) Schema(ctx context.Context, req resource.SchemaR
 				MarkdownDescription: "AWS region where the Neptune cluster is located",
 				Optional:            true,
 			},
+			"strategy": schema.StringAttribute{
+				MarkdownDescription: "How the cluster is rebooted: `rolling` reboots the readers one at a time and waits for each to be available, then fails over to a reader and reboots the old writer. " +
+					"`parallel` reboots every instance at once. `writer_only` reboots just the writer. Defaults to `parallel`",
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString(clusterRebootParallel),
+			},
+			"only_if_pending_reboot": schema.BoolAttribute{
+				MarkdownDescription: "When true, the instances are only rebooted when the cluster parameter group of a member is in `pending-reboot` state",
+				Optional:            true,
+			},
 			"last_reboot_time": schema.StringAttribute{
 				MarkdownDescription: "Timestamp of the last reboot operation",
 				Computed:            true,
@@ -74,6 +91,12 @@ func (r *NeptuneRebootResource) Schema(ctx context.Context, req resource.SchemaR
 				},
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Update: true,
+			}),
+		},
 	}
 }
 
@@ -85,14 +108,13 @@ func (r *NeptuneRebootResource) Configure(ctx context.Context, req resource.Conf
 		return
 	}
 
-	// Create AWS config and Neptune client
-	awsCfg, err := config.LoadDefaultConfig(ctx)
-	if err != nil {
-		resp.Diagnostics.AddError("Unable to load AWS SDK config", fmt.Sprintf("Unable to load AWS SDK config: %s", err))
+	// Keep the provider data, Neptune clients are built per region on first use
+	pd := providerDataFrom(req.ProviderData, &resp.Diagnostics)
+	if pd == nil {
 		return
 	}
 
-	r.client = neptune.NewFromConfig(awsCfg)
+	r.providerData = pd
 }
 
 func (r *NeptuneRebootResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -104,97 +126,34 @@ func (r *NeptuneRebootResource) Create(ctx context.Context, req resource.CreateR
 		return
 	}
 
-	// If region is specified, update the AWS config
-	var client *neptune.Client
-	if !data.Region.IsNull() {
-		tflog.Debug(ctx, "configuring client with region")
-		awsCfg, err := config.LoadDefaultConfig(ctx, config.WithRegion(data.Region.ValueString()))
-		if err != nil {
-			resp.Diagnostics.AddError("Unable to load AWS SDK config", fmt.Sprintf("Unable to load AWS SDK config with region %s: %s", data.Region.ValueString(), err))
-			return
-		}
-		client = neptune.NewFromConfig(awsCfg)
-	} else {
-		tflog.Debug(ctx, "using default client")
-		client = r.client
-	}
-
-	// Get all instances in the cluster
-	describeInput := &neptune.DescribeDBClustersInput{
-		DBClusterIdentifier: aws.String(data.ClusterIdentifier.ValueString()),
-	}
-
-	clusterOutput, err := client.DescribeDBClusters(ctx, describeInput)
-	if err != nil {
-		resp.Diagnostics.AddError("Error describing Neptune cluster", fmt.Sprintf("Could not describe Neptune cluster: %s", err))
+	if !r.providerData.configured(&resp.Diagnostics) {
 		return
 	}
 
-	if len(clusterOutput.DBClusters) == 0 {
-		resp.Diagnostics.AddError("Neptune cluster not found", fmt.Sprintf("Neptune cluster %s not found", data.ClusterIdentifier.ValueString()))
+	if err := checkClusterRebootStrategy(data.Strategy.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("strategy"), "Invalid reboot strategy", err.Error())
 		return
 	}
 
-	cluster := clusterOutput.DBClusters[0]
-	instances := cluster.DBClusterMembers
-
-	if len(instances) == 0 {
-		resp.Diagnostics.AddError("No instances in cluster", fmt.Sprintf("Neptune cluster %s has no instances to reboot", data.ClusterIdentifier.ValueString()))
+	timeout, diags := data.Timeouts.Create(ctx, defaultWaitTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	tflog.Info(ctx, fmt.Sprintf("Found %d instances in Neptune cluster %s", len(instances), data.ClusterIdentifier.ValueString()))
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
 
-	// Reboot each instance in the cluster
-	for _, member := range instances {
-		instanceId := aws.ToString(member.DBInstanceIdentifier)
+	// Stays null when the reboot is skipped
+	data.LastRebootTime = types.StringNull()
 
-		tflog.Debug(ctx, "Rebooting Neptune instance", map[string]interface{}{
-			"cluster_identifier":  data.ClusterIdentifier.ValueString(),
-			"instance_identifier": instanceId,
-		})
-
-		rebootInput := &neptune.RebootDBInstanceInput{
-			DBInstanceIdentifier: aws.String(instanceId),
-		}
-
-		_, err := client.RebootDBInstance(ctx, rebootInput)
-		if err != nil {
-			resp.Diagnostics.AddError("Error rebooting Neptune instance", fmt.Sprintf("Could not reboot Neptune instance %s: %s", instanceId, err))
-			return
-		}
-
-		tflog.Info(ctx, fmt.Sprintf("Successfully initiated reboot for Neptune instance: %s", instanceId))
-	}
-
-	// Wait for all instances to become available again
-	tflog.Info(ctx, "Waiting for all Neptune instances to become available")
-
-	for _, member := range instances {
-		instanceId := aws.ToString(member.DBInstanceIdentifier)
-
-		waiter := neptune.NewDBInstanceAvailableWaiter(client)
-		waitInput := &neptune.DescribeDBInstancesInput{
-			DBInstanceIdentifier: aws.String(instanceId),
-		}
-
-		tflog.Debug(ctx, fmt.Sprintf("Waiting for Neptune instance %s to become available", instanceId))
-		err = waiter.Wait(ctx, waitInput, 30*time.Minute)
-		if err != nil {
-			resp.Diagnostics.AddError("Error waiting for Neptune instance to become available", fmt.Sprintf("Could not confirm Neptune instance %s availability: %s", instanceId, err))
-			return
-		}
-
-		tflog.Info(ctx, fmt.Sprintf("Neptune instance %s is now available", instanceId))
+	r.reboot(ctx, &data, timeout, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	// Set computed values
-	currentTime := time.Now().Format(time.RFC3339)
-	data.LastRebootTime = types.StringValue(currentTime)
 	data.ID = types.StringValue(data.ClusterIdentifier.ValueString())
 
-	tflog.Info(ctx, fmt.Sprintf("Successfully rebooted all instances in Neptune cluster %s", data.ClusterIdentifier.ValueString()))
-
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -208,21 +167,13 @@ func (r *NeptuneRebootResource) Read(ctx context.Context, req resource.ReadReque
 		return
 	}
 
-	// If region is specified, update the AWS config
-	var client *neptune.Client
-	if !data.Region.IsNull() {
-		tflog.Debug(ctx, "configuring client with region")
-		awsCfg, err := config.LoadDefaultConfig(ctx, config.WithRegion(data.Region.ValueString()))
-		if err != nil {
-			resp.Diagnostics.AddError("Unable to load AWS SDK config", fmt.Sprintf("Unable to load AWS SDK config with region %s: %s", data.Region.ValueString(), err))
-			return
-		}
-		client = neptune.NewFromConfig(awsCfg)
-	} else {
-		tflog.Debug(ctx, "using default client")
-		client = r.client
+	if !r.providerData.configured(&resp.Diagnostics) {
+		return
 	}
 
+	// Get the cached client for the requested region
+	client := r.providerData.neptuneClient(data.Region.ValueString())
+
 	// Check if the Neptune cluster exists
 	input := &neptune.DescribeDBClustersInput{
 		DBClusterIdentifier: aws.String(data.ClusterIdentifier.ValueString()),
@@ -239,29 +190,51 @@ func (r *NeptuneRebootResource) Read(ctx context.Context, req resource.ReadReque
 }
 
 func (r *NeptuneRebootResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var data NeptuneRebootResourceModel
+	var data, state NeptuneRebootResourceModel
 
-	// Read Terraform plan data into the model
+	// Read Terraform plan and prior state data into the models
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	// If region is specified, update the AWS config
-	var client *neptune.Client
-	if !data.Region.IsNull() {
-		tflog.Debug(ctx, "configuring client with region")
-		awsCfg, err := config.LoadDefaultConfig(ctx, config.WithRegion(data.Region.ValueString()))
-		if err != nil {
-			resp.Diagnostics.AddError("Unable to load AWS SDK config", fmt.Sprintf("Unable to load AWS SDK config with region %s: %s", data.Region.ValueString(), err))
-			return
-		}
-		client = neptune.NewFromConfig(awsCfg)
-	} else {
-		tflog.Debug(ctx, "using default client")
-		client = r.client
+	if !r.providerData.configured(&resp.Diagnostics) {
+		return
+	}
+
+	if err := checkClusterRebootStrategy(data.Strategy.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("strategy"), "Invalid reboot strategy", err.Error())
+		return
 	}
 
+	timeout, diags := data.Timeouts.Update(ctx, defaultWaitTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	// Kept when the reboot is skipped
+	data.LastRebootTime = state.LastRebootTime
+
+	r.reboot(ctx, &data, timeout, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// reboot reboots every instance of the cluster, waits for them to become available again and records the
+// outcome in data. With only_if_pending_reboot the reboot is skipped when no member is pending-reboot.
+func (r *NeptuneRebootResource) reboot(ctx context.Context, data *NeptuneRebootResourceModel, timeout time.Duration, diags *diag.Diagnostics) {
+	// Get the cached client for the requested region
+	client := r.providerData.neptuneClient(data.Region.ValueString())
+
 	// Get all instances in the cluster
 	describeInput := &neptune.DescribeDBClustersInput{
 		DBClusterIdentifier: aws.String(data.ClusterIdentifier.ValueString()),
@@ -269,18 +242,57 @@ func (r *NeptuneRebootResource) Update(ctx context.Context, req resource.UpdateR
 
 	clusterOutput, err := client.DescribeDBClusters(ctx, describeInput)
 	if err != nil {
-		resp.Diagnostics.AddError("Error describing Neptune cluster", fmt.Sprintf("Could not describe Neptune cluster: %s", err))
+		diags.AddError("Error describing Neptune cluster", fmt.Sprintf("Could not describe Neptune cluster: %s", err))
 		return
 	}
 
 	if len(clusterOutput.DBClusters) == 0 {
-		resp.Diagnostics.AddError("Neptune cluster not found", fmt.Sprintf("Neptune cluster %s not found", data.ClusterIdentifier.ValueString()))
+		diags.AddError("Neptune cluster not found", fmt.Sprintf("Neptune cluster %s not found", data.ClusterIdentifier.ValueString()))
 		return
 	}
 
 	cluster := clusterOutput.DBClusters[0]
 	instances := cluster.DBClusterMembers
 
+	if len(instances) == 0 {
+		diags.AddError("No instances in cluster", fmt.Sprintf("Neptune cluster %s has no instances to reboot", data.ClusterIdentifier.ValueString()))
+		return
+	}
+
+	if data.OnlyIfPendingReboot.ValueBool() && !neptuneClusterPendingReboot(cluster) {
+		tflog.Info(ctx, "Skipping Neptune cluster reboot, no cluster parameter group is pending-reboot", map[string]interface{}{
+			"cluster_identifier": data.ClusterIdentifier.ValueString(),
+		})
+		return
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Found %d instances in Neptune cluster %s", len(instances), data.ClusterIdentifier.ValueString()), map[string]interface{}{
+		"strategy": data.Strategy.ValueString(),
+	})
+
+	switch data.Strategy.ValueString() {
+	case clusterRebootRolling:
+		r.rollingReboot(ctx, client, data, cluster, timeout, diags)
+	case clusterRebootWriterOnly:
+		r.writerOnlyReboot(ctx, client, data, cluster, timeout, diags)
+	default:
+		r.parallelReboot(ctx, client, data, cluster, timeout, diags)
+	}
+	if diags.HasError() {
+		return
+	}
+
+	// Set computed values
+	currentTime := time.Now().Format(time.RFC3339)
+	data.LastRebootTime = types.StringValue(currentTime)
+
+	tflog.Info(ctx, fmt.Sprintf("Successfully rebooted Neptune cluster %s", data.ClusterIdentifier.ValueString()))
+}
+
+// parallelReboot reboots every instance of the cluster at once, then waits for all of them
+func (r *NeptuneRebootResource) parallelReboot(ctx context.Context, client *neptune.Client, data *NeptuneRebootResourceModel, cluster neptunetypes.DBCluster, timeout time.Duration, diags *diag.Diagnostics) {
+	instances := cluster.DBClusterMembers
+
 	// Reboot each instance in the cluster
 	for _, member := range instances {
 		instanceId := aws.ToString(member.DBInstanceIdentifier)
@@ -296,35 +308,105 @@ func (r *NeptuneRebootResource) Update(ctx context.Context, req resource.UpdateR
 
 		_, err := client.RebootDBInstance(ctx, rebootInput)
 		if err != nil {
-			resp.Diagnostics.AddError("Error rebooting Neptune instance", fmt.Sprintf("Could not reboot Neptune instance %s: %s", instanceId, err))
+			diags.AddError("Error rebooting Neptune instance", fmt.Sprintf("Could not reboot Neptune instance %s: %s", instanceId, err))
 			return
 		}
+
+		tflog.Info(ctx, fmt.Sprintf("Successfully initiated reboot for Neptune instance: %s", instanceId))
 	}
 
 	// Wait for all instances to become available again
-	tflog.Debug(ctx, "Waiting for all Neptune instances to become available")
+	tflog.Info(ctx, "Waiting for all Neptune instances to become available")
 
 	for _, member := range instances {
 		instanceId := aws.ToString(member.DBInstanceIdentifier)
 
-		waiter := neptune.NewDBInstanceAvailableWaiter(client)
-		waitInput := &neptune.DescribeDBInstancesInput{
-			DBInstanceIdentifier: aws.String(instanceId),
+		tflog.Debug(ctx, fmt.Sprintf("Waiting for Neptune instance %s to become available", instanceId))
+		err := waitForNeptuneInstanceAvailable(ctx, client, instanceId, timeout)
+		if err != nil {
+			diags.AddError("Error waiting for Neptune instance to become available", fmt.Sprintf("Could not confirm Neptune instance %s availability: %s", instanceId, err))
+			return
+		}
+
+		tflog.Info(ctx, fmt.Sprintf("Neptune instance %s is now available", instanceId))
+	}
+}
+
+// rollingReboot reboots the readers one at a time, then fails over to a reader and reboots the old writer,
+// so that the cluster keeps a writer and all but one reader available throughout
+func (r *NeptuneRebootResource) rollingReboot(ctx context.Context, client *neptune.Client, data *NeptuneRebootResourceModel, cluster neptunetypes.DBCluster, timeout time.Duration, diags *diag.Diagnostics) {
+	clusterID := data.ClusterIdentifier.ValueString()
+	members := neptuneClusterMembers(cluster)
+	readers, writer := splitClusterMembers(members)
+
+	for _, reader := range readers {
+		if err := rebootNeptuneInstance(ctx, client, reader, timeout); err != nil {
+			diags.AddError("Error rebooting Neptune instance", fmt.Sprintf("Could not reboot Neptune instance %s: %s", reader, err))
+			return
 		}
+	}
 
-		err = waiter.Wait(ctx, waitInput, 30*time.Minute)
+	if writer == "" {
+		return
+	}
+
+	if len(readers) > 0 {
+		// Promote the reader with the lowest promotion tier
+		target, err := failoverTarget(members, "")
 		if err != nil {
-			resp.Diagnostics.AddError("Error waiting for Neptune instance to become available", fmt.Sprintf("Could not confirm Neptune instance %s availability: %s", instanceId, err))
+			diags.AddError("Error failing over Neptune cluster", fmt.Sprintf("Could not choose a failover target: %s", err))
+			return
+		}
+
+		tflog.Debug(ctx, "Failing over Neptune cluster", map[string]interface{}{
+			"cluster_identifier": clusterID,
+			"target_instance":    target,
+		})
+
+		_, err = client.FailoverDBCluster(ctx, &neptune.FailoverDBClusterInput{
+			DBClusterIdentifier:        aws.String(clusterID),
+			TargetDBInstanceIdentifier: aws.String(target),
+		})
+		if err != nil {
+			diags.AddError("Error failing over Neptune cluster", fmt.Sprintf("Could not failover Neptune cluster: %s", err))
+			return
+		}
+
+		err = waitForClusterWriter(ctx, fmt.Sprintf("Neptune cluster %s", clusterID), target, timeout, func(ctx context.Context) ([]clusterMember, error) {
+			result, err := client.DescribeDBClusters(ctx, &neptune.DescribeDBClustersInput{
+				DBClusterIdentifier: aws.String(clusterID),
+			})
+			if err != nil {
+				return nil, err
+			}
+			if len(result.DBClusters) == 0 {
+				return nil, fmt.Errorf("neptune cluster %s not found", clusterID)
+			}
+			return neptuneClusterMembers(result.DBClusters[0]), nil
+		})
+		if err != nil {
+			diags.AddError("Error waiting for Neptune failover", fmt.Sprintf("Could not confirm Neptune cluster failover: %s", err))
 			return
 		}
 	}
 
-	// Set computed values
-	currentTime := time.Now().Format(time.RFC3339)
-	data.LastRebootTime = types.StringValue(currentTime)
+	// The old writer is a reader by now unless the cluster has a single instance
+	if err := rebootNeptuneInstance(ctx, client, writer, timeout); err != nil {
+		diags.AddError("Error rebooting Neptune instance", fmt.Sprintf("Could not reboot Neptune instance %s: %s", writer, err))
+	}
+}
 
-	// Save updated data into Terraform state
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+// writerOnlyReboot reboots the writer and leaves the readers alone
+func (r *NeptuneRebootResource) writerOnlyReboot(ctx context.Context, client *neptune.Client, data *NeptuneRebootResourceModel, cluster neptunetypes.DBCluster, timeout time.Duration, diags *diag.Diagnostics) {
+	_, writer := splitClusterMembers(neptuneClusterMembers(cluster))
+	if writer == "" {
+		diags.AddError("Neptune cluster has no writer", fmt.Sprintf("Neptune cluster %s has no writer instance to reboot", data.ClusterIdentifier.ValueString()))
+		return
+	}
+
+	if err := rebootNeptuneInstance(ctx, client, writer, timeout); err != nil {
+		diags.AddError("Error rebooting Neptune instance", fmt.Sprintf("Could not reboot Neptune instance %s: %s", writer, err))
+	}
 }
 
 func (r *NeptuneRebootResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -335,3 +417,43 @@ func (r *NeptuneRebootResource) Delete(ctx context.Context, req resource.DeleteR
 func (r *NeptuneRebootResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	resource.ImportStatePassthroughID(ctx, path.Root("cluster_identifier"), req, resp)
 }
+
+// rebootNeptuneInstance reboots a single instance and waits for it to become available again
+func rebootNeptuneInstance(ctx context.Context, client *neptune.Client, instanceID string, timeout time.Duration) error {
+	tflog.Debug(ctx, "Rebooting Neptune instance", map[string]interface{}{
+		"instance_identifier": instanceID,
+	})
+
+	_, err := client.RebootDBInstance(ctx, &neptune.RebootDBInstanceInput{
+		DBInstanceIdentifier: aws.String(instanceID),
+	})
+	if err != nil {
+		return err
+	}
+	return waitForNeptuneInstanceAvailable(ctx, client, instanceID, timeout)
+}
+
+// waitForNeptuneInstanceAvailable polls the Neptune instance status until it is available
+func waitForNeptuneInstanceAvailable(ctx context.Context, client *neptune.Client, instanceID string, timeout time.Duration) error {
+	poller := &statusPoller{
+		Resource: fmt.Sprintf("Neptune instance %s", instanceID),
+		Target:   []string{"available"},
+		Failure:  dbFailureStatuses,
+		Timeout:  timeout,
+		Refresh: func(ctx context.Context) (string, error) {
+			result, err := client.DescribeDBInstances(ctx, &neptune.DescribeDBInstancesInput{
+				DBInstanceIdentifier: aws.String(instanceID),
+			})
+			if err != nil {
+				return "", err
+			}
+			if len(result.DBInstances) == 0 {
+				return "", fmt.Errorf("neptune instance %s not found", instanceID)
+			}
+			return aws.ToString(result.DBInstances[0].DBInstanceStatus), nil
+		},
+	}
+
+	_, err := poller.Wait(ctx)
+	return err
+}
@@ -10,13 +10,15 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"maps"
 	"net/http"
+	"slices"
 	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
-	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/opensearch"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -37,20 +39,49 @@ func NewOpenSearchModifyResource() resource.Resource {
 
 // OpenSearchModifyResource defines the resource implementation.
 type OpenSearchModifyResource struct {
-	client *opensearch.Client
+	providerData *providerData
 }
 
 // OpenSearchModifyResourceModel describes the resource data model.
 type OpenSearchModifyResourceModel struct {
-	DomainName                       frameworktypes.String `tfsdk:"domain_name"`
-	Region                           frameworktypes.String `tfsdk:"region"`
-	MasterUsername                   frameworktypes.String `tfsdk:"master_username"`
-	MasterPassword                   frameworktypes.String `tfsdk:"master_password"`
-	EnableSecurityPluginAuditing     frameworktypes.Bool   `tfsdk:"enable_security_plugin_auditing"`
-	AuditRestDisabledCategories      frameworktypes.List   `tfsdk:"audit_rest_disabled_categories"`
-	AuditDisabledTransportCategories frameworktypes.List   `tfsdk:"audit_disabled_transport_categories"`
-	LastModifiedTime                 frameworktypes.String `tfsdk:"last_modified_time"`
-	ID                               frameworktypes.String `tfsdk:"id"`
+	DomainName                       frameworktypes.String      `tfsdk:"domain_name"`
+	Region                           frameworktypes.String      `tfsdk:"region"`
+	MasterUsername                   frameworktypes.String      `tfsdk:"master_username"`
+	MasterPassword                   frameworktypes.String      `tfsdk:"master_password"`
+	EnableSecurityPluginAuditing     frameworktypes.Bool        `tfsdk:"enable_security_plugin_auditing"`
+	AuditRestDisabledCategories      frameworktypes.List        `tfsdk:"audit_rest_disabled_categories"`
+	AuditDisabledTransportCategories frameworktypes.List        `tfsdk:"audit_disabled_transport_categories"`
+	Audit                            *openSearchAuditModel      `tfsdk:"audit"`
+	Compliance                       *openSearchComplianceModel `tfsdk:"compliance"`
+	LastModifiedTime                 frameworktypes.String      `tfsdk:"last_modified_time"`
+	ID                               frameworktypes.String      `tfsdk:"id"`
+	Timeouts                         timeouts.Value             `tfsdk:"timeouts"`
+}
+
+// openSearchAuditModel maps the audit block
+type openSearchAuditModel struct {
+	EnableRest              frameworktypes.Bool `tfsdk:"enable_rest"`
+	EnableTransport         frameworktypes.Bool `tfsdk:"enable_transport"`
+	ResolveBulkRequests     frameworktypes.Bool `tfsdk:"resolve_bulk_requests"`
+	LogRequestBody          frameworktypes.Bool `tfsdk:"log_request_body"`
+	ResolveIndices          frameworktypes.Bool `tfsdk:"resolve_indices"`
+	ExcludeSensitiveHeaders frameworktypes.Bool `tfsdk:"exclude_sensitive_headers"`
+	IgnoreUsers             frameworktypes.List `tfsdk:"ignore_users"`
+	IgnoreRequests          frameworktypes.List `tfsdk:"ignore_requests"`
+}
+
+// openSearchComplianceModel maps the compliance block
+type openSearchComplianceModel struct {
+	Enabled             frameworktypes.Bool `tfsdk:"enabled"`
+	InternalConfig      frameworktypes.Bool `tfsdk:"internal_config"`
+	ExternalConfig      frameworktypes.Bool `tfsdk:"external_config"`
+	ReadMetadataOnly    frameworktypes.Bool `tfsdk:"read_metadata_only"`
+	ReadWatchedFields   frameworktypes.Map  `tfsdk:"read_watched_fields"`
+	ReadIgnoreUsers     frameworktypes.List `tfsdk:"read_ignore_users"`
+	WriteMetadataOnly   frameworktypes.Bool `tfsdk:"write_metadata_only"`
+	WriteLogDiffs       frameworktypes.Bool `tfsdk:"write_log_diffs"`
+	WriteWatchedIndices frameworktypes.List `tfsdk:"write_watched_indices"`
+	WriteIgnoreUsers    frameworktypes.List `tfsdk:"write_ignore_users"`
 }
 
 func (r *OpenSearchModifyResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -110,6 +141,100 @@ func (r *OpenSearchModifyResource) Schema(ctx context.Context, req resource.Sche
 				},
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Update: true,
+			}),
+			"audit": schema.SingleNestedBlock{
+				MarkdownDescription: "Audit logging settings of the security plugin. The disabled categories are set with `audit_rest_disabled_categories` and `audit_disabled_transport_categories`",
+				Attributes: map[string]schema.Attribute{
+					"enable_rest": schema.BoolAttribute{
+						MarkdownDescription: "Whether to log events on the REST layer. Defaults to true",
+						Optional:            true,
+					},
+					"enable_transport": schema.BoolAttribute{
+						MarkdownDescription: "Whether to log events on the transport layer. Defaults to true",
+						Optional:            true,
+					},
+					"resolve_bulk_requests": schema.BoolAttribute{
+						MarkdownDescription: "Whether to log every document of a bulk request individually. Defaults to true",
+						Optional:            true,
+					},
+					"log_request_body": schema.BoolAttribute{
+						MarkdownDescription: "Whether to include the request body in audit messages. Defaults to true",
+						Optional:            true,
+					},
+					"resolve_indices": schema.BoolAttribute{
+						MarkdownDescription: "Whether to resolve index aliases and wildcards in audit messages. Defaults to true",
+						Optional:            true,
+					},
+					"exclude_sensitive_headers": schema.BoolAttribute{
+						MarkdownDescription: "Whether to exclude sensitive headers such as Authorization from audit messages. Defaults to true",
+						Optional:            true,
+					},
+					"ignore_users": schema.ListAttribute{
+						MarkdownDescription: "Users whose requests are not logged, for example internal service accounts. Defaults to none",
+						Optional:            true,
+						ElementType:         frameworktypes.StringType,
+					},
+					"ignore_requests": schema.ListAttribute{
+						MarkdownDescription: "Request patterns that are not logged, for example `indices:data/read/*`. Defaults to none",
+						Optional:            true,
+						ElementType:         frameworktypes.StringType,
+					},
+				},
+			},
+			"compliance": schema.SingleNestedBlock{
+				MarkdownDescription: "Compliance logging settings of the security plugin",
+				Attributes: map[string]schema.Attribute{
+					"enabled": schema.BoolAttribute{
+						MarkdownDescription: "Whether to enable compliance logging. Defaults to true",
+						Optional:            true,
+					},
+					"internal_config": schema.BoolAttribute{
+						MarkdownDescription: "Whether to log changes to the security plugin configuration index. Defaults to true",
+						Optional:            true,
+					},
+					"external_config": schema.BoolAttribute{
+						MarkdownDescription: "Whether to log the external configuration of the nodes when they start. Defaults to false",
+						Optional:            true,
+					},
+					"read_metadata_only": schema.BoolAttribute{
+						MarkdownDescription: "Whether read events only log metadata and not the document fields. Defaults to true",
+						Optional:            true,
+					},
+					"read_watched_fields": schema.MapAttribute{
+						MarkdownDescription: "Fields whose reads are logged, keyed by index pattern. Defaults to none",
+						Optional:            true,
+						ElementType:         frameworktypes.ListType{ElemType: frameworktypes.StringType},
+					},
+					"read_ignore_users": schema.ListAttribute{
+						MarkdownDescription: "Users whose read events are not logged. Defaults to none",
+						Optional:            true,
+						ElementType:         frameworktypes.StringType,
+					},
+					"write_metadata_only": schema.BoolAttribute{
+						MarkdownDescription: "Whether write events only log metadata and not the document content. Defaults to true",
+						Optional:            true,
+					},
+					"write_log_diffs": schema.BoolAttribute{
+						MarkdownDescription: "Whether write events log the diff of the document, only when write_metadata_only is false. Defaults to false",
+						Optional:            true,
+					},
+					"write_watched_indices": schema.ListAttribute{
+						MarkdownDescription: "Index patterns whose writes are logged. Defaults to none",
+						Optional:            true,
+						ElementType:         frameworktypes.StringType,
+					},
+					"write_ignore_users": schema.ListAttribute{
+						MarkdownDescription: "Users whose write events are not logged. Defaults to none",
+						Optional:            true,
+						ElementType:         frameworktypes.StringType,
+					},
+				},
+			},
+		},
 	}
 }
 
@@ -121,20 +246,135 @@ func (r *OpenSearchModifyResource) Configure(ctx context.Context, req resource.C
 		return
 	}
 
-	// Create AWS config and OpenSearch client
-	awsCfg, err := config.LoadDefaultConfig(ctx)
-	if err != nil {
-		resp.Diagnostics.AddError("Unable to load AWS SDK config", fmt.Sprintf("Unable to load AWS SDK config: %s", err))
+	// Keep the provider data, OpenSearch clients are built per region on first use
+	pd := providerDataFrom(req.ProviderData, &resp.Diagnostics)
+	if pd == nil {
 		return
 	}
 
-	r.client = opensearch.NewFromConfig(awsCfg)
+	r.providerData = pd
+}
+
+// securityAuditConfig is the audit configuration of the OpenSearch Security REST API
+type securityAuditConfig struct {
+	Enabled    bool                       `json:"enabled"`
+	Audit      securityAuditSettings      `json:"audit"`
+	Compliance securityComplianceSettings `json:"compliance"`
+}
+
+// securityAuditSettings holds the audit section of the audit configuration
+type securityAuditSettings struct {
+	EnableRest                  bool     `json:"enable_rest"`
+	DisabledRestCategories      []string `json:"disabled_rest_categories"`
+	EnableTransport             bool     `json:"enable_transport"`
+	DisabledTransportCategories []string `json:"disabled_transport_categories"`
+	ResolveBulkRequests         bool     `json:"resolve_bulk_requests"`
+	LogRequestBody              bool     `json:"log_request_body"`
+	ResolveIndices              bool     `json:"resolve_indices"`
+	ExcludeSensitiveHeaders     bool     `json:"exclude_sensitive_headers"`
+	IgnoreUsers                 []string `json:"ignore_users"`
+	IgnoreRequests              []string `json:"ignore_requests"`
+}
+
+// securityComplianceSettings holds the compliance section of the audit configuration
+type securityComplianceSettings struct {
+	Enabled             bool                `json:"enabled"`
+	InternalConfig      bool                `json:"internal_config"`
+	ExternalConfig      bool                `json:"external_config"`
+	ReadMetadataOnly    bool                `json:"read_metadata_only"`
+	ReadWatchedFields   map[string][]string `json:"read_watched_fields"`
+	ReadIgnoreUsers     []string            `json:"read_ignore_users"`
+	WriteMetadataOnly   bool                `json:"write_metadata_only"`
+	WriteLogDiffs       bool                `json:"write_log_diffs"`
+	WriteWatchedIndices []string            `json:"write_watched_indices"`
+	WriteIgnoreUsers    []string            `json:"write_ignore_users"`
+}
+
+// defaultSecurityAuditConfig returns the best-practice settings used for everything that is not configured
+func defaultSecurityAuditConfig() securityAuditConfig {
+	return securityAuditConfig{
+		Enabled: true,
+		Audit: securityAuditSettings{
+			EnableRest:                  true,
+			DisabledRestCategories:      []string{},
+			EnableTransport:             true,
+			DisabledTransportCategories: []string{},
+			ResolveBulkRequests:         true,
+			LogRequestBody:              true,
+			ResolveIndices:              true,
+			ExcludeSensitiveHeaders:     true,
+			IgnoreUsers:                 []string{},
+			IgnoreRequests:              []string{},
+		},
+		Compliance: securityComplianceSettings{
+			Enabled:             true,
+			InternalConfig:      true,
+			ExternalConfig:      false,
+			ReadMetadataOnly:    true,
+			ReadWatchedFields:   map[string][]string{},
+			ReadIgnoreUsers:     []string{},
+			WriteMetadataOnly:   true,
+			WriteLogDiffs:       false,
+			WriteWatchedIndices: []string{},
+			WriteIgnoreUsers:    []string{},
+		},
+	}
+}
+
+// setBool overwrites target with the configured value, if any
+func setBool(target *bool, value frameworktypes.Bool) {
+	if !value.IsNull() && !value.IsUnknown() {
+		*target = value.ValueBool()
+	}
 }
 
-// AuditConfig holds the audit configuration parameters
-type AuditConfig struct {
-	DisabledRestCategories      []string
-	DisabledTransportCategories []string
+// setStrings overwrites target with the configured list, if any
+func setStrings(ctx context.Context, target *[]string, value frameworktypes.List, diags *diag.Diagnostics) {
+	if value.IsNull() || value.IsUnknown() {
+		return
+	}
+	values := []string{}
+	diags.Append(value.ElementsAs(ctx, &values, false)...)
+	*target = values
+}
+
+// securityAuditConfigFrom builds the audit configuration from the resource data, keeping the
+// defaults for every setting that is not configured
+func securityAuditConfigFrom(ctx context.Context, data *OpenSearchModifyResourceModel, diags *diag.Diagnostics) securityAuditConfig {
+	config := defaultSecurityAuditConfig()
+
+	setStrings(ctx, &config.Audit.DisabledRestCategories, data.AuditRestDisabledCategories, diags)
+	setStrings(ctx, &config.Audit.DisabledTransportCategories, data.AuditDisabledTransportCategories, diags)
+
+	if audit := data.Audit; audit != nil {
+		setBool(&config.Audit.EnableRest, audit.EnableRest)
+		setBool(&config.Audit.EnableTransport, audit.EnableTransport)
+		setBool(&config.Audit.ResolveBulkRequests, audit.ResolveBulkRequests)
+		setBool(&config.Audit.LogRequestBody, audit.LogRequestBody)
+		setBool(&config.Audit.ResolveIndices, audit.ResolveIndices)
+		setBool(&config.Audit.ExcludeSensitiveHeaders, audit.ExcludeSensitiveHeaders)
+		setStrings(ctx, &config.Audit.IgnoreUsers, audit.IgnoreUsers, diags)
+		setStrings(ctx, &config.Audit.IgnoreRequests, audit.IgnoreRequests, diags)
+	}
+
+	if compliance := data.Compliance; compliance != nil {
+		setBool(&config.Compliance.Enabled, compliance.Enabled)
+		setBool(&config.Compliance.InternalConfig, compliance.InternalConfig)
+		setBool(&config.Compliance.ExternalConfig, compliance.ExternalConfig)
+		setBool(&config.Compliance.ReadMetadataOnly, compliance.ReadMetadataOnly)
+		if !compliance.ReadWatchedFields.IsNull() && !compliance.ReadWatchedFields.IsUnknown() {
+			fields := map[string][]string{}
+			diags.Append(compliance.ReadWatchedFields.ElementsAs(ctx, &fields, false)...)
+			config.Compliance.ReadWatchedFields = fields
+		}
+		setStrings(ctx, &config.Compliance.ReadIgnoreUsers, compliance.ReadIgnoreUsers, diags)
+		setBool(&config.Compliance.WriteMetadataOnly, compliance.WriteMetadataOnly)
+		setBool(&config.Compliance.WriteLogDiffs, compliance.WriteLogDiffs)
+		setStrings(ctx, &config.Compliance.WriteWatchedIndices, compliance.WriteWatchedIndices, diags)
+		setStrings(ctx, &config.Compliance.WriteIgnoreUsers, compliance.WriteIgnoreUsers, diags)
+	}
+
+	return config
 }
 
 // normalizeCategories converts category names with spaces to underscores
@@ -148,103 +388,152 @@ func normalizeCategories(categories []string) []string {
 	return normalized
 }
 
-// enableSecurityPluginAuditing enables audit logging via OpenSearch Security API
-func enableSecurityPluginAuditing(ctx context.Context, endpoint, username, password string, config AuditConfig) error {
-	// Construct the security API URL
-	url := fmt.Sprintf("https://%s/_plugins/_security/api/audit/config", endpoint)
-
-	// Normalize category names (replace spaces with underscores)
-	normalizedRestCategories := normalizeCategories(config.DisabledRestCategories)
-	normalizedTransportCategories := normalizeCategories(config.DisabledTransportCategories)
-
-	tflog.Debug(ctx, "Normalized audit categories", map[string]interface{}{
-		"original_rest_categories":        config.DisabledRestCategories,
-		"normalized_rest_categories":      normalizedRestCategories,
-		"original_transport_categories":   config.DisabledTransportCategories,
-		"normalized_transport_categories": normalizedTransportCategories,
-	})
-
-	// Audit configuration with hardcoded best-practice settings
-	// Only disabled categories are configurable
-	auditConfig := map[string]interface{}{
-		"enabled": true,
-		"audit": map[string]interface{}{
-			"enable_rest":                   true,
-			"disabled_rest_categories":      normalizedRestCategories,
-			"enable_transport":              true,
-			"disabled_transport_categories": normalizedTransportCategories,
-			"resolve_bulk_requests":         true,
-			"log_request_body":              true,
-			"resolve_indices":               true,
-			"exclude_sensitive_headers":     true,
-			"ignore_users":                  []string{},
-			"ignore_requests":               []string{},
-		},
-		"compliance": map[string]interface{}{
-			"enabled":               true,
-			"internal_config":       true,
-			"external_config":       false,
-			"read_metadata_only":    true,
-			"read_watched_fields":   map[string]interface{}{},
-			"read_ignore_users":     []string{},
-			"write_metadata_only":   true,
-			"write_log_diffs":       false,
-			"write_watched_indices": []string{},
-			"write_ignore_users":    []string{},
-		},
-	}
+// securityAuditConfigPath is the OpenSearch Security REST API path of the audit configuration
+const securityAuditConfigPath = "/_plugins/_security/api/audit/config"
 
-	jsonData, err := json.Marshal(auditConfig)
-	if err != nil {
-		return fmt.Errorf("failed to marshal audit config: %w", err)
-	}
-
-	tflog.Debug(ctx, "Audit config JSON payload", map[string]interface{}{
-		"payload": string(jsonData),
-	})
+// securityPluginClient calls the OpenSearch Security REST API of a domain as the master user
+type securityPluginClient struct {
+	endpoint   string
+	username   string
+	password   string
+	httpClient *http.Client
+}
 
+func newSecurityPluginClient(endpoint, username, password string) *securityPluginClient {
 	// Create HTTP client with TLS config
 	tr := &http.Transport{
 		TLSClientConfig: &tls.Config{InsecureSkipVerify: false},
 	}
-	client := &http.Client{
-		Transport: tr,
-		Timeout:   30 * time.Second,
+	return &securityPluginClient{
+		endpoint: endpoint,
+		username: username,
+		password: password,
+		httpClient: &http.Client{
+			Transport: tr,
+			Timeout:   30 * time.Second,
+		},
+	}
+}
+
+// do sends a request to the Security REST API and returns the response body
+func (c *securityPluginClient) do(ctx context.Context, method, apiPath string, payload []byte) ([]byte, error) {
+	url := fmt.Sprintf("https://%s%s", c.endpoint, apiPath)
+
+	var body io.Reader
+	if payload != nil {
+		body = bytes.NewBuffer(payload)
 	}
 
-	// Create PUT request
-	req, err := http.NewRequestWithContext(ctx, "PUT", url, bytes.NewBuffer(jsonData))
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
 	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
+		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
-	req.SetBasicAuth(username, password)
-	req.Header.Set("Content-Type", "application/json")
+	req.SetBasicAuth(c.username, c.password)
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
 
 	// Execute request
-	resp, err := client.Do(req)
+	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return fmt.Errorf("failed to execute request: %w", err)
+		return nil, fmt.Errorf("failed to execute request: %w", err)
 	}
 	defer resp.Body.Close()
 
-	body, err := io.ReadAll(resp.Body)
+	respBody, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return fmt.Errorf("failed to read response body: %w", err)
+		return nil, fmt.Errorf("failed to read response body: %w", err)
 	}
 
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return fmt.Errorf("API returned status %d: %s", resp.StatusCode, string(body))
+		return nil, fmt.Errorf("API returned status %d: %s", resp.StatusCode, string(respBody))
+	}
+
+	return respBody, nil
+}
+
+// getAuditConfig returns the current audit configuration of the domain
+func (c *securityPluginClient) getAuditConfig(ctx context.Context) (*securityAuditConfig, error) {
+	body, err := c.do(ctx, http.MethodGet, securityAuditConfigPath, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// The configuration is wrapped together with the list of read-only fields
+	var result struct {
+		Config *securityAuditConfig `json:"config"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse audit config: %w", err)
+	}
+	if result.Config == nil {
+		return nil, fmt.Errorf("audit config missing from response: %s", string(body))
+	}
+	return result.Config, nil
+}
+
+// enableSecurityPluginAuditing enables audit logging via OpenSearch Security API
+func enableSecurityPluginAuditing(ctx context.Context, client *securityPluginClient, config securityAuditConfig) error {
+	// Normalize category names (replace spaces with underscores)
+	normalizedRestCategories := normalizeCategories(config.Audit.DisabledRestCategories)
+	normalizedTransportCategories := normalizeCategories(config.Audit.DisabledTransportCategories)
+
+	tflog.Debug(ctx, "Normalized audit categories", map[string]interface{}{
+		"original_rest_categories":        config.Audit.DisabledRestCategories,
+		"normalized_rest_categories":      normalizedRestCategories,
+		"original_transport_categories":   config.Audit.DisabledTransportCategories,
+		"normalized_transport_categories": normalizedTransportCategories,
+	})
+
+	config.Audit.DisabledRestCategories = normalizedRestCategories
+	config.Audit.DisabledTransportCategories = normalizedTransportCategories
+
+	jsonData, err := json.Marshal(config)
+	if err != nil {
+		return fmt.Errorf("failed to marshal audit config: %w", err)
+	}
+
+	tflog.Debug(ctx, "Audit config JSON payload", map[string]interface{}{
+		"payload": string(jsonData),
+	})
+
+	body, err := client.do(ctx, http.MethodPut, securityAuditConfigPath, jsonData)
+	if err != nil {
+		return err
 	}
 
 	tflog.Info(ctx, "Successfully enabled OpenSearch security plugin auditing", map[string]interface{}{
-		"endpoint": endpoint,
+		"endpoint": client.endpoint,
 		"response": string(body),
 	})
 
 	return nil
 }
 
+// auditCategoriesValue returns the categories read from the domain as a list value. The configured
+// value is kept when it names the same categories, so that spelling, order and null versus empty
+// lists do not show up as a diff.
+func auditCategoriesValue(ctx context.Context, current frameworktypes.List, remote []string, diags *diag.Diagnostics) frameworktypes.List {
+	var configured []string
+	if !current.IsNull() && !current.IsUnknown() {
+		if d := current.ElementsAs(ctx, &configured, false); d.HasError() {
+			diags.Append(d...)
+			return current
+		}
+	}
+
+	normalized := normalizeCategories(configured)
+	slices.Sort(normalized)
+	actual := normalizeCategories(remote)
+	slices.Sort(actual)
+	if slices.Equal(slices.Compact(normalized), slices.Compact(actual)) {
+		return current
+	}
+
+	return stringListValue(ctx, remote, diags)
+}
+
 func (r *OpenSearchModifyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var data OpenSearchModifyResourceModel
 
@@ -254,8 +543,17 @@ func (r *OpenSearchModifyResource) Create(ctx context.Context, req resource.Crea
 		return
 	}
 
+	timeout, diags := data.Timeouts.Create(ctx, defaultWaitTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
 	// Process the domain modification
-	r.processDomainModification(ctx, &data, &resp.Diagnostics)
+	r.processDomainModification(ctx, &data, timeout, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -268,53 +566,53 @@ func (r *OpenSearchModifyResource) Create(ctx context.Context, req resource.Crea
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
-// getClient returns an OpenSearch client, optionally configured with a specific region
+// getClient returns the cached OpenSearch client, optionally for a specific region
 func (r *OpenSearchModifyResource) getClient(ctx context.Context, region frameworktypes.String, diags *diag.Diagnostics) *opensearch.Client {
-	if !region.IsNull() {
-		tflog.Debug(ctx, "Configuring client with region", map[string]interface{}{"region": region.ValueString()})
-		awsCfg, err := config.LoadDefaultConfig(ctx, config.WithRegion(region.ValueString()))
-		if err != nil {
-			diags.AddError("Unable to load AWS SDK config", fmt.Sprintf("Unable to load AWS SDK config with region %s: %s", region.ValueString(), err))
-			return nil
-		}
-		return opensearch.NewFromConfig(awsCfg)
+	if !r.providerData.configured(diags) {
+		return nil
 	}
-	tflog.Debug(ctx, "Using default client")
-	return r.client
+	tflog.Debug(ctx, "Using OpenSearch client", map[string]interface{}{"region": region.ValueString()})
+	return r.providerData.opensearchClient(region.ValueString())
 }
 
 // waitForDomainReady waits for the OpenSearch domain to finish processing and returns its endpoint
-func (r *OpenSearchModifyResource) waitForDomainReady(ctx context.Context, client *opensearch.Client, domainName string, diags *diag.Diagnostics) string {
+func (r *OpenSearchModifyResource) waitForDomainReady(ctx context.Context, client *opensearch.Client, domainName string, timeout time.Duration, diags *diag.Diagnostics) string {
 	tflog.Info(ctx, "Waiting for OpenSearch domain to finish processing")
-	maxAttempts := 120 // 30 minutes with 15 second intervals
-
-	for i := 0; i < maxAttempts; i++ {
-		result, err := client.DescribeDomain(ctx, &opensearch.DescribeDomainInput{
-			DomainName: aws.String(domainName),
-		})
-		if err != nil {
-			diags.AddError("Error describing OpenSearch domain", fmt.Sprintf("Could not describe OpenSearch domain: %s", err))
-			return ""
-		}
 
-		if result.DomainStatus != nil && result.DomainStatus.Processing != nil && !*result.DomainStatus.Processing {
-			tflog.Info(ctx, "OpenSearch domain is ready")
-			if result.DomainStatus.Endpoint != nil {
-				return *result.DomainStatus.Endpoint
+	var endpoint string
+	poller := &statusPoller{
+		Resource:    fmt.Sprintf("OpenSearch domain %s", domainName),
+		Target:      []string{"ready"},
+		Timeout:     timeout,
+		MinInterval: 15 * time.Second,
+		MaxInterval: time.Minute,
+		Refresh: func(ctx context.Context) (string, error) {
+			result, err := client.DescribeDomain(ctx, &opensearch.DescribeDomainInput{
+				DomainName: aws.String(domainName),
+			})
+			if err != nil {
+				return "", err
 			}
-			diags.AddWarning("OpenSearch domain ready but endpoint not available", "Domain finished processing but endpoint is not set")
-			return ""
-		}
 
-		if i == maxAttempts-1 {
-			diags.AddError("Timeout waiting for OpenSearch domain", "OpenSearch domain did not finish processing within 30 minutes")
-			return ""
-		}
+			status := result.DomainStatus
+			if status == nil || status.Processing == nil || *status.Processing {
+				return "processing", nil
+			}
+			endpoint = aws.ToString(status.Endpoint)
+			return "ready", nil
+		},
+	}
 
-		time.Sleep(15 * time.Second)
+	if _, err := poller.Wait(ctx); err != nil {
+		diags.AddError("Error waiting for OpenSearch domain", fmt.Sprintf("OpenSearch domain did not finish processing: %s", err))
+		return ""
 	}
 
-	return ""
+	tflog.Info(ctx, "OpenSearch domain is ready")
+	if endpoint == "" {
+		diags.AddWarning("OpenSearch domain ready but endpoint not available", "Domain finished processing but endpoint is not set")
+	}
+	return endpoint
 }
 
 // enableSecurityAuditing enables OpenSearch security plugin auditing if configured
@@ -333,34 +631,21 @@ func (r *OpenSearchModifyResource) enableSecurityAuditing(ctx context.Context, d
 
 	tflog.Info(ctx, "Enabling OpenSearch security plugin auditing")
 
-	// Extract disabled categories
-	var disabledRestCategories []string
-	if !data.AuditRestDisabledCategories.IsNull() {
-		if diagsTemp := data.AuditRestDisabledCategories.ElementsAs(ctx, &disabledRestCategories, false); diagsTemp.HasError() {
-			diags.Append(diagsTemp...)
-			return
-		}
-	}
-
-	var disabledTransportCategories []string
-	if !data.AuditDisabledTransportCategories.IsNull() {
-		if diagsTemp := data.AuditDisabledTransportCategories.ElementsAs(ctx, &disabledTransportCategories, false); diagsTemp.HasError() {
-			diags.Append(diagsTemp...)
-			return
-		}
-	}
-
-	auditConfig := AuditConfig{
-		DisabledRestCategories:      disabledRestCategories,
-		DisabledTransportCategories: disabledTransportCategories,
+	// Merge the configured settings with the defaults
+	auditConfig := securityAuditConfigFrom(ctx, data, diags)
+	if diags.HasError() {
+		return
 	}
 
 	tflog.Debug(ctx, "Audit configuration", map[string]interface{}{
-		"disabled_rest_categories":      auditConfig.DisabledRestCategories,
-		"disabled_transport_categories": auditConfig.DisabledTransportCategories,
+		"disabled_rest_categories":      auditConfig.Audit.DisabledRestCategories,
+		"disabled_transport_categories": auditConfig.Audit.DisabledTransportCategories,
+		"ignore_users":                  auditConfig.Audit.IgnoreUsers,
+		"ignore_requests":               auditConfig.Audit.IgnoreRequests,
 	})
 
-	if err := enableSecurityPluginAuditing(ctx, domainEndpoint, data.MasterUsername.ValueString(), data.MasterPassword.ValueString(), auditConfig); err != nil {
+	client := newSecurityPluginClient(domainEndpoint, data.MasterUsername.ValueString(), data.MasterPassword.ValueString())
+	if err := enableSecurityPluginAuditing(ctx, client, auditConfig); err != nil {
 		diags.AddWarning(
 			"Failed to enable security plugin auditing",
 			fmt.Sprintf("Failed to enable security plugin auditing: %s. You may need to enable it manually via the OpenSearch Dashboard.", err),
@@ -368,8 +653,126 @@ func (r *OpenSearchModifyResource) enableSecurityAuditing(ctx context.Context, d
 	}
 }
 
+// readSecurityAuditing refreshes the audit settings in state from the domain so that changes made
+// outside Terraform, for example in OpenSearch Dashboards, show up as a diff
+func (r *OpenSearchModifyResource) readSecurityAuditing(ctx context.Context, data *OpenSearchModifyResourceModel, domainEndpoint string, diags *diag.Diagnostics) {
+	// Only read back the settings this resource manages
+	if data.EnableSecurityPluginAuditing.IsNull() || !data.EnableSecurityPluginAuditing.ValueBool() {
+		return
+	}
+
+	if data.MasterUsername.IsNull() || data.MasterPassword.IsNull() || domainEndpoint == "" {
+		tflog.Debug(ctx, "Skipping security plugin audit config read, master credentials or domain endpoint missing")
+		return
+	}
+
+	client := newSecurityPluginClient(domainEndpoint, data.MasterUsername.ValueString(), data.MasterPassword.ValueString())
+	refreshSecurityAuditing(ctx, client, data, diags)
+}
+
+// refreshSecurityAuditing reads the audit configuration with client and maps it into data
+func refreshSecurityAuditing(ctx context.Context, client *securityPluginClient, data *OpenSearchModifyResourceModel, diags *diag.Diagnostics) {
+	status, err := client.getAuditConfig(ctx)
+	if err != nil {
+		diags.AddWarning(
+			"Failed to read security plugin audit configuration",
+			fmt.Sprintf("Failed to read security plugin audit configuration: %s. Drift in the audit settings will not be detected.", err),
+		)
+		return
+	}
+
+	tflog.Debug(ctx, "Read security plugin audit configuration", map[string]interface{}{
+		"enabled":                       status.Enabled,
+		"disabled_rest_categories":      status.Audit.DisabledRestCategories,
+		"disabled_transport_categories": status.Audit.DisabledTransportCategories,
+	})
+
+	data.EnableSecurityPluginAuditing = frameworktypes.BoolValue(status.Enabled)
+	data.AuditRestDisabledCategories = auditCategoriesValue(ctx, data.AuditRestDisabledCategories, status.Audit.DisabledRestCategories, diags)
+	data.AuditDisabledTransportCategories = auditCategoriesValue(ctx, data.AuditDisabledTransportCategories, status.Audit.DisabledTransportCategories, diags)
+
+	// The blocks are only read back when configured, an absent block leaves every setting at its default
+	defaults := defaultSecurityAuditConfig()
+	if audit := data.Audit; audit != nil {
+		audit.EnableRest = auditBoolValue(audit.EnableRest, status.Audit.EnableRest, defaults.Audit.EnableRest)
+		audit.EnableTransport = auditBoolValue(audit.EnableTransport, status.Audit.EnableTransport, defaults.Audit.EnableTransport)
+		audit.ResolveBulkRequests = auditBoolValue(audit.ResolveBulkRequests, status.Audit.ResolveBulkRequests, defaults.Audit.ResolveBulkRequests)
+		audit.LogRequestBody = auditBoolValue(audit.LogRequestBody, status.Audit.LogRequestBody, defaults.Audit.LogRequestBody)
+		audit.ResolveIndices = auditBoolValue(audit.ResolveIndices, status.Audit.ResolveIndices, defaults.Audit.ResolveIndices)
+		audit.ExcludeSensitiveHeaders = auditBoolValue(audit.ExcludeSensitiveHeaders, status.Audit.ExcludeSensitiveHeaders, defaults.Audit.ExcludeSensitiveHeaders)
+		audit.IgnoreUsers = auditStringsValue(ctx, audit.IgnoreUsers, status.Audit.IgnoreUsers, diags)
+		audit.IgnoreRequests = auditStringsValue(ctx, audit.IgnoreRequests, status.Audit.IgnoreRequests, diags)
+	}
+
+	if compliance := data.Compliance; compliance != nil {
+		compliance.Enabled = auditBoolValue(compliance.Enabled, status.Compliance.Enabled, defaults.Compliance.Enabled)
+		compliance.InternalConfig = auditBoolValue(compliance.InternalConfig, status.Compliance.InternalConfig, defaults.Compliance.InternalConfig)
+		compliance.ExternalConfig = auditBoolValue(compliance.ExternalConfig, status.Compliance.ExternalConfig, defaults.Compliance.ExternalConfig)
+		compliance.ReadMetadataOnly = auditBoolValue(compliance.ReadMetadataOnly, status.Compliance.ReadMetadataOnly, defaults.Compliance.ReadMetadataOnly)
+		compliance.ReadWatchedFields = watchedFieldsValue(ctx, compliance.ReadWatchedFields, status.Compliance.ReadWatchedFields, diags)
+		compliance.ReadIgnoreUsers = auditStringsValue(ctx, compliance.ReadIgnoreUsers, status.Compliance.ReadIgnoreUsers, diags)
+		compliance.WriteMetadataOnly = auditBoolValue(compliance.WriteMetadataOnly, status.Compliance.WriteMetadataOnly, defaults.Compliance.WriteMetadataOnly)
+		compliance.WriteLogDiffs = auditBoolValue(compliance.WriteLogDiffs, status.Compliance.WriteLogDiffs, defaults.Compliance.WriteLogDiffs)
+		compliance.WriteWatchedIndices = auditStringsValue(ctx, compliance.WriteWatchedIndices, status.Compliance.WriteWatchedIndices, diags)
+		compliance.WriteIgnoreUsers = auditStringsValue(ctx, compliance.WriteIgnoreUsers, status.Compliance.WriteIgnoreUsers, diags)
+	}
+}
+
+// auditBoolValue returns the setting read from the domain. An unset attribute stays null while the
+// domain keeps the default, so that only real drift shows up as a diff.
+func auditBoolValue(current frameworktypes.Bool, remote, defaultValue bool) frameworktypes.Bool {
+	if current.IsNull() && remote == defaultValue {
+		return current
+	}
+	return frameworktypes.BoolValue(remote)
+}
+
+// auditStringsValue returns the list read from the domain. The configured value is kept when it holds
+// the same entries, an unset attribute matches an empty list.
+func auditStringsValue(ctx context.Context, current frameworktypes.List, remote []string, diags *diag.Diagnostics) frameworktypes.List {
+	var configured []string
+	if !current.IsNull() && !current.IsUnknown() {
+		if d := current.ElementsAs(ctx, &configured, false); d.HasError() {
+			diags.Append(d...)
+			return current
+		}
+	}
+
+	actual := slices.Clone(remote)
+	slices.Sort(configured)
+	slices.Sort(actual)
+	if slices.Equal(configured, actual) {
+		return current
+	}
+
+	return stringListValue(ctx, remote, diags)
+}
+
+// watchedFieldsValue returns the watched fields read from the domain, keeping the configured value
+// when it watches the same fields
+func watchedFieldsValue(ctx context.Context, current frameworktypes.Map, remote map[string][]string, diags *diag.Diagnostics) frameworktypes.Map {
+	configured := map[string][]string{}
+	if !current.IsNull() && !current.IsUnknown() {
+		if d := current.ElementsAs(ctx, &configured, false); d.HasError() {
+			diags.Append(d...)
+			return current
+		}
+	}
+
+	if maps.EqualFunc(configured, remote, slices.Equal) {
+		return current
+	}
+
+	if remote == nil {
+		remote = map[string][]string{}
+	}
+	mapValue, d := frameworktypes.MapValueFrom(ctx, frameworktypes.ListType{ElemType: frameworktypes.StringType}, remote)
+	diags.Append(d...)
+	return mapValue
+}
+
 // processDomainModification handles the common logic for Create and Update operations
-func (r *OpenSearchModifyResource) processDomainModification(ctx context.Context, data *OpenSearchModifyResourceModel, diags *diag.Diagnostics) {
+func (r *OpenSearchModifyResource) processDomainModification(ctx context.Context, data *OpenSearchModifyResourceModel, timeout time.Duration, diags *diag.Diagnostics) {
 	// Get AWS client with optional region override
 	client := r.getClient(ctx, data.Region, diags)
 	if diags.HasError() {
@@ -377,7 +780,7 @@ func (r *OpenSearchModifyResource) processDomainModification(ctx context.Context
 	}
 
 	// Wait for domain to be ready and get endpoint
-	domainEndpoint := r.waitForDomainReady(ctx, client, data.DomainName.ValueString(), diags)
+	domainEndpoint := r.waitForDomainReady(ctx, client, data.DomainName.ValueString(), timeout, diags)
 	if diags.HasError() {
 		return
 	}
@@ -406,12 +809,22 @@ func (r *OpenSearchModifyResource) Read(ctx context.Context, req resource.ReadRe
 		DomainName: aws.String(data.DomainName.ValueString()),
 	}
 
-	_, err := client.DescribeDomain(ctx, input)
+	result, err := client.DescribeDomain(ctx, input)
 	if err != nil {
 		resp.Diagnostics.AddError("Error reading OpenSearch domain", fmt.Sprintf("Could not read OpenSearch domain: %s", err))
 		return
 	}
 
+	// Detect audit settings changed outside Terraform
+	var domainEndpoint string
+	if result.DomainStatus != nil {
+		domainEndpoint = aws.ToString(result.DomainStatus.Endpoint)
+	}
+	r.readSecurityAuditing(ctx, &data, domainEndpoint, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -425,8 +838,17 @@ func (r *OpenSearchModifyResource) Update(ctx context.Context, req resource.Upda
 		return
 	}
 
+	timeout, diags := data.Timeouts.Update(ctx, defaultWaitTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
 	// Process the domain modification
-	r.processDomainModification(ctx, &data, &resp.Diagnostics)
+	r.processDomainModification(ctx, &data, timeout, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -0,0 +1,288 @@
+// Copyright (c) IBM Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// newTestSecurityPluginClient returns a client for a TLS test server running handler
+func newTestSecurityPluginClient(t *testing.T, handler http.HandlerFunc) *securityPluginClient {
+	t.Helper()
+
+	server := httptest.NewTLSServer(handler)
+	t.Cleanup(server.Close)
+
+	client := newSecurityPluginClient(strings.TrimPrefix(server.URL, "https://"), "admin", "secret")
+	client.httpClient = server.Client()
+	return client
+}
+
+func TestSecurityPluginClientGetAuditConfig(t *testing.T) {
+	client := newTestSecurityPluginClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != securityAuditConfigPath {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if user, pass, ok := r.BasicAuth(); !ok || user != "admin" || pass != "secret" {
+			t.Errorf("expected the master credentials, got %q %q", user, pass)
+		}
+		io.WriteString(w, `{
+			"_readonly": ["/audit/exclude_sensitive_headers"],
+			"config": {
+				"enabled": false,
+				"audit": {
+					"disabled_rest_categories": ["AUTHENTICATED", "GRANTED_PRIVILEGES"],
+					"disabled_transport_categories": []
+				}
+			}
+		}`)
+	})
+
+	status, err := client.getAuditConfig(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if status.Enabled {
+		t.Error("expected auditing to be disabled")
+	}
+	if want := []string{"AUTHENTICATED", "GRANTED_PRIVILEGES"}; !slices.Equal(status.Audit.DisabledRestCategories, want) {
+		t.Errorf("expected %v, got %v", want, status.Audit.DisabledRestCategories)
+	}
+	if len(status.Audit.DisabledTransportCategories) != 0 {
+		t.Errorf("expected no transport categories, got %v", status.Audit.DisabledTransportCategories)
+	}
+}
+
+func TestSecurityPluginClientErrors(t *testing.T) {
+	client := newTestSecurityPluginClient(t, func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"status":"FORBIDDEN"}`, http.StatusForbidden)
+	})
+	if _, err := client.getAuditConfig(context.Background()); err == nil || !strings.Contains(err.Error(), "403") {
+		t.Errorf("expected a status error, got %v", err)
+	}
+
+	client = newTestSecurityPluginClient(t, func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, `{"status":"OK"}`)
+	})
+	if _, err := client.getAuditConfig(context.Background()); err == nil {
+		t.Error("expected an error for a response without config")
+	}
+}
+
+func TestEnableSecurityPluginAuditing(t *testing.T) {
+	var payload string
+	client := newTestSecurityPluginClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut || r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("unexpected request %s %s", r.Method, r.Header.Get("Content-Type"))
+		}
+		body, _ := io.ReadAll(r.Body)
+		payload = string(body)
+		io.WriteString(w, `{"status":"OK"}`)
+	})
+
+	config := defaultSecurityAuditConfig()
+	config.Audit.DisabledRestCategories = []string{"failed login"}
+	if err := enableSecurityPluginAuditing(context.Background(), client, config); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !strings.Contains(payload, `"disabled_rest_categories":["FAILED_LOGIN"]`) {
+		t.Errorf("expected normalized categories in %s", payload)
+	}
+	if !strings.Contains(payload, `"ignore_users":[]`) || !strings.Contains(payload, `"read_watched_fields":{}`) {
+		t.Errorf("expected empty lists and maps rather than null in %s", payload)
+	}
+}
+
+func TestSecurityAuditConfigFromDefaults(t *testing.T) {
+	ctx := context.Background()
+	var diags diag.Diagnostics
+
+	data := OpenSearchModifyResourceModel{
+		AuditRestDisabledCategories:      types.ListNull(types.StringType),
+		AuditDisabledTransportCategories: types.ListNull(types.StringType),
+	}
+	config := securityAuditConfigFrom(ctx, &data, &diags)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if !reflect.DeepEqual(config, defaultSecurityAuditConfig()) {
+		t.Errorf("expected the defaults, got %+v", config)
+	}
+
+	// An empty block keeps the defaults as well
+	data.Audit = &openSearchAuditModel{
+		IgnoreUsers:    types.ListNull(types.StringType),
+		IgnoreRequests: types.ListNull(types.StringType),
+	}
+	if config := securityAuditConfigFrom(ctx, &data, &diags); !reflect.DeepEqual(config, defaultSecurityAuditConfig()) {
+		t.Errorf("expected the defaults for an empty block, got %+v", config)
+	}
+}
+
+func TestSecurityAuditConfigFrom(t *testing.T) {
+	ctx := context.Background()
+	var diags diag.Diagnostics
+
+	ignoreUsers, _ := types.ListValueFrom(ctx, types.StringType, []string{"guardium", "kibanaserver"})
+	watchedFields, _ := types.MapValueFrom(ctx, types.ListType{ElemType: types.StringType}, map[string][]string{
+		"customers*": {"ssn", "email"},
+	})
+	data := OpenSearchModifyResourceModel{
+		AuditRestDisabledCategories:      types.ListNull(types.StringType),
+		AuditDisabledTransportCategories: types.ListValueMust(types.StringType, nil),
+		Audit: &openSearchAuditModel{
+			LogRequestBody: types.BoolValue(false),
+			IgnoreUsers:    ignoreUsers,
+			IgnoreRequests: types.ListNull(types.StringType),
+		},
+		Compliance: &openSearchComplianceModel{
+			ExternalConfig:      types.BoolValue(true),
+			WriteMetadataOnly:   types.BoolValue(false),
+			WriteLogDiffs:       types.BoolValue(true),
+			ReadWatchedFields:   watchedFields,
+			ReadIgnoreUsers:     types.ListNull(types.StringType),
+			WriteWatchedIndices: types.ListValueMust(types.StringType, []attr.Value{types.StringValue("orders*")}),
+			WriteIgnoreUsers:    types.ListNull(types.StringType),
+		},
+	}
+
+	config := securityAuditConfigFrom(ctx, &data, &diags)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	want := defaultSecurityAuditConfig()
+	want.Audit.LogRequestBody = false
+	want.Audit.IgnoreUsers = []string{"guardium", "kibanaserver"}
+	want.Compliance.ExternalConfig = true
+	want.Compliance.WriteMetadataOnly = false
+	want.Compliance.WriteLogDiffs = true
+	want.Compliance.ReadWatchedFields = map[string][]string{"customers*": {"ssn", "email"}}
+	want.Compliance.WriteWatchedIndices = []string{"orders*"}
+	if !reflect.DeepEqual(config, want) {
+		t.Errorf("expected %+v, got %+v", want, config)
+	}
+}
+
+func TestAuditCategoriesValue(t *testing.T) {
+	ctx := context.Background()
+	var diags diag.Diagnostics
+
+	configured, _ := types.ListValueFrom(ctx, types.StringType, []string{"failed login", "AUTHENTICATED"})
+	if got := auditCategoriesValue(ctx, configured, []string{"AUTHENTICATED", "FAILED_LOGIN"}, &diags); !got.Equal(configured) {
+		t.Errorf("expected the configured value to be kept, got %s", got)
+	}
+
+	if got := auditCategoriesValue(ctx, types.ListNull(types.StringType), nil, &diags); !got.IsNull() {
+		t.Errorf("expected a null value to be kept, got %s", got)
+	}
+
+	got := auditCategoriesValue(ctx, configured, []string{"AUTHENTICATED"}, &diags)
+	want, _ := types.ListValueFrom(ctx, types.StringType, []string{"AUTHENTICATED"})
+	if !got.Equal(want) {
+		t.Errorf("expected the remote categories, got %s", got)
+	}
+
+	if diags.HasError() {
+		t.Errorf("unexpected diagnostics: %v", diags)
+	}
+}
+
+func TestRefreshSecurityAuditing(t *testing.T) {
+	ctx := context.Background()
+	var diags diag.Diagnostics
+
+	client := newTestSecurityPluginClient(t, func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, `{
+			"config": {
+				"enabled": true,
+				"audit": {
+					"enable_rest": true,
+					"enable_transport": false,
+					"resolve_bulk_requests": true,
+					"log_request_body": true,
+					"resolve_indices": true,
+					"exclude_sensitive_headers": true,
+					"disabled_rest_categories": [],
+					"disabled_transport_categories": [],
+					"ignore_users": ["kibanaserver", "guardium"],
+					"ignore_requests": []
+				},
+				"compliance": {
+					"enabled": true,
+					"internal_config": true,
+					"external_config": false,
+					"read_metadata_only": true,
+					"read_watched_fields": {"customers*": ["ssn"]},
+					"read_ignore_users": [],
+					"write_metadata_only": true,
+					"write_log_diffs": false,
+					"write_watched_indices": ["orders*"],
+					"write_ignore_users": []
+				}
+			}
+		}`)
+	})
+
+	ignoreUsers, _ := types.ListValueFrom(ctx, types.StringType, []string{"guardium", "kibanaserver"})
+	watchedFields, _ := types.MapValueFrom(ctx, types.ListType{ElemType: types.StringType}, map[string][]string{
+		"customers*": {"ssn", "email"},
+	})
+	data := OpenSearchModifyResourceModel{
+		EnableSecurityPluginAuditing:     types.BoolValue(true),
+		AuditRestDisabledCategories:      types.ListNull(types.StringType),
+		AuditDisabledTransportCategories: types.ListNull(types.StringType),
+		Audit: &openSearchAuditModel{
+			LogRequestBody: types.BoolValue(true),
+			IgnoreUsers:    ignoreUsers,
+			IgnoreRequests: types.ListNull(types.StringType),
+		},
+		Compliance: &openSearchComplianceModel{
+			ReadWatchedFields:   watchedFields,
+			ReadIgnoreUsers:     types.ListNull(types.StringType),
+			WriteWatchedIndices: types.ListNull(types.StringType),
+			WriteIgnoreUsers:    types.ListNull(types.StringType),
+		},
+	}
+
+	refreshSecurityAuditing(ctx, client, &data, &diags)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	// Settings matching the configuration or the defaults are kept as they are
+	if !data.Audit.LogRequestBody.Equal(types.BoolValue(true)) || !data.Audit.EnableRest.IsNull() {
+		t.Errorf("expected unchanged settings to be kept, got %+v", data.Audit)
+	}
+	if !data.Audit.IgnoreUsers.Equal(ignoreUsers) || !data.Audit.IgnoreRequests.IsNull() {
+		t.Errorf("expected unchanged lists to be kept, got %+v", data.Audit)
+	}
+	if !data.Compliance.Enabled.IsNull() || !data.Compliance.WriteIgnoreUsers.IsNull() {
+		t.Errorf("expected default settings to stay null, got %+v", data.Compliance)
+	}
+
+	// Settings changed on the domain show up as drift
+	if !data.Audit.EnableTransport.Equal(types.BoolValue(false)) {
+		t.Errorf("expected enable_transport to be read back, got %s", data.Audit.EnableTransport)
+	}
+	wantFields, _ := types.MapValueFrom(ctx, types.ListType{ElemType: types.StringType}, map[string][]string{"customers*": {"ssn"}})
+	if !data.Compliance.ReadWatchedFields.Equal(wantFields) {
+		t.Errorf("expected the remote watched fields, got %s", data.Compliance.ReadWatchedFields)
+	}
+	wantIndices, _ := types.ListValueFrom(ctx, types.StringType, []string{"orders*"})
+	if !data.Compliance.WriteWatchedIndices.Equal(wantIndices) {
+		t.Errorf("expected the remote watched indices, got %s", data.Compliance.WriteWatchedIndices)
+	}
+}
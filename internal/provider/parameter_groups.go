@@ -0,0 +1,362 @@
+// Copyright (c) IBM Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/docdb"
+	docdbtypes "github.com/aws/aws-sdk-go-v2/service/docdb/types"
+	"github.com/aws/aws-sdk-go-v2/service/neptune"
+	neptunetypes "github.com/aws/aws-sdk-go-v2/service/neptune/types"
+	"github.com/aws/aws-sdk-go-v2/service/rds"
+	rdstypes "github.com/aws/aws-sdk-go-v2/service/rds/types"
+)
+
+// Parameter group types managed by the db_parameter_group_audit resource
+const (
+	// parameterGroupRDSInstance is the DB parameter group of an RDS instance
+	parameterGroupRDSInstance = "rds_instance"
+	// parameterGroupRDSCluster is the DB cluster parameter group of an Aurora cluster
+	parameterGroupRDSCluster = "rds_cluster"
+	// parameterGroupNeptuneCluster is the DB cluster parameter group of a Neptune cluster
+	parameterGroupNeptuneCluster = "neptune_cluster"
+	// parameterGroupDocDBCluster is the cluster parameter group of a DocumentDB cluster
+	parameterGroupDocDBCluster = "docdb_cluster"
+)
+
+// defaultParameterGroupPrefix starts the name of the parameter groups provided by AWS, which cannot be modified
+const defaultParameterGroupPrefix = "default."
+
+// maxParametersPerModify is the number of parameters the Modify*ParameterGroup operations accept per call
+const maxParametersPerModify = 20
+
+// errParameterGroupNotFound is returned when the parameter group or the instance or cluster using it does not exist
+var errParameterGroupNotFound = errors.New("parameter group not found")
+
+// checkParameterGroupType validates the group_type value
+func checkParameterGroupType(groupType string) error {
+	switch groupType {
+	case parameterGroupRDSInstance, parameterGroupRDSCluster, parameterGroupNeptuneCluster, parameterGroupDocDBCluster:
+		return nil
+	}
+	return fmt.Errorf("group_type must be %s, %s, %s or %s, got %q",
+		parameterGroupRDSInstance, parameterGroupRDSCluster, parameterGroupNeptuneCluster, parameterGroupDocDBCluster, groupType)
+}
+
+// isDefaultParameterGroup reports whether the group is provided by AWS and must be copied before it is modified
+func isDefaultParameterGroup(name string) bool {
+	return strings.HasPrefix(name, defaultParameterGroupPrefix)
+}
+
+// parameterChange is the engine independent form of a parameter to modify
+type parameterChange struct {
+	Name  string
+	Value string
+	// ApplyMethod is immediate for dynamic parameters and pending-reboot for static ones
+	ApplyMethod string
+}
+
+// parameterChanges compares the wanted values with the current parameters of a group and returns the
+// parameters to modify, sorted by name. Parameters that are missing from the group or cannot be modified
+// are reported as an error, parameters that already have the wanted value are left out.
+func parameterChanges(wanted map[string]string, current []parameterValueModel) ([]parameterChange, error) {
+	byName := make(map[string]parameterValueModel, len(current))
+	for _, p := range current {
+		byName[p.Name.ValueString()] = p
+	}
+
+	names := make([]string, 0, len(wanted))
+	for name := range wanted {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var changes []parameterChange
+	for _, name := range names {
+		p, ok := byName[name]
+		if !ok {
+			return nil, fmt.Errorf("parameter %s does not exist in the parameter group", name)
+		}
+		if !p.IsModifiable.ValueBool() {
+			return nil, fmt.Errorf("parameter %s cannot be modified", name)
+		}
+		if !p.Value.IsNull() && p.Value.ValueString() == wanted[name] {
+			continue
+		}
+		applyMethod := parameterApplyPendingReboot
+		if p.ApplyType.ValueString() == "dynamic" {
+			applyMethod = "immediate"
+		}
+		changes = append(changes, parameterChange{Name: name, Value: wanted[name], ApplyMethod: applyMethod})
+	}
+	return changes, nil
+}
+
+// parameterGroupClient hides the differences between the RDS, Neptune and DocumentDB parameter group APIs
+type parameterGroupClient interface {
+	// attachedGroup returns the name of the parameter group used by the instance or cluster
+	attachedGroup(ctx context.Context, identifier string) (string, error)
+	// copyGroup copies source into a new group named target, it reports whether the target already existed
+	copyGroup(ctx context.Context, source, target, description string) (existed bool, err error)
+	// values returns the parameters of the group restricted to names
+	values(ctx context.Context, group string, names []string) ([]parameterValueModel, error)
+	// modify applies a batch of at most maxParametersPerModify changes to the group
+	modify(ctx context.Context, group string, changes []parameterChange) error
+}
+
+// parameterGroups returns the parameter group client for the group type
+func (p *providerData) parameterGroups(groupType, region string) parameterGroupClient {
+	switch groupType {
+	case parameterGroupRDSCluster:
+		return rdsClusterParameterGroups{client: p.rdsClient(region)}
+	case parameterGroupNeptuneCluster:
+		return neptuneClusterParameterGroups{client: p.neptuneClient(region)}
+	case parameterGroupDocDBCluster:
+		return docdbClusterParameterGroups{client: p.docdbClient(region)}
+	default:
+		return rdsInstanceParameterGroups{client: p.rdsClient(region)}
+	}
+}
+
+// applyParameterChanges modifies the group in batches of maxParametersPerModify parameters
+func applyParameterChanges(ctx context.Context, groups parameterGroupClient, group string, changes []parameterChange) error {
+	for batch := range slices.Chunk(changes, maxParametersPerModify) {
+		if err := groups.modify(ctx, group, batch); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// rdsInstanceParameterGroups manages the DB parameter groups of RDS instances
+type rdsInstanceParameterGroups struct {
+	client *rds.Client
+}
+
+func (g rdsInstanceParameterGroups) attachedGroup(ctx context.Context, identifier string) (string, error) {
+	instance, err := describeRDSInstance(ctx, g.client, identifier)
+	if err != nil {
+		return "", err
+	}
+	if instance == nil || len(instance.DBParameterGroups) == 0 {
+		return "", errParameterGroupNotFound
+	}
+	return aws.ToString(instance.DBParameterGroups[0].DBParameterGroupName), nil
+}
+
+func (g rdsInstanceParameterGroups) copyGroup(ctx context.Context, source, target, description string) (bool, error) {
+	_, err := g.client.CopyDBParameterGroup(ctx, &rds.CopyDBParameterGroupInput{
+		SourceDBParameterGroupIdentifier:  aws.String(source),
+		TargetDBParameterGroupIdentifier:  aws.String(target),
+		TargetDBParameterGroupDescription: aws.String(description),
+	})
+	var exists *rdstypes.DBParameterGroupAlreadyExistsFault
+	if errors.As(err, &exists) {
+		return true, nil
+	}
+	return false, err
+}
+
+func (g rdsInstanceParameterGroups) values(ctx context.Context, group string, names []string) ([]parameterValueModel, error) {
+	values, err := rdsParameterValues(ctx, g.client, group, names)
+	var notFound *rdstypes.DBParameterGroupNotFoundFault
+	if errors.As(err, &notFound) {
+		return nil, errParameterGroupNotFound
+	}
+	return values, err
+}
+
+func (g rdsInstanceParameterGroups) modify(ctx context.Context, group string, changes []parameterChange) error {
+	_, err := g.client.ModifyDBParameterGroup(ctx, &rds.ModifyDBParameterGroupInput{
+		DBParameterGroupName: aws.String(group),
+		Parameters:           rdsParameters(changes),
+	})
+	return err
+}
+
+// rdsClusterParameterGroups manages the DB cluster parameter groups of Aurora clusters
+type rdsClusterParameterGroups struct {
+	client *rds.Client
+}
+
+func (g rdsClusterParameterGroups) attachedGroup(ctx context.Context, identifier string) (string, error) {
+	cluster, err := describeAuroraCluster(ctx, g.client, identifier)
+	if err != nil {
+		return "", err
+	}
+	if cluster == nil {
+		return "", errParameterGroupNotFound
+	}
+	return aws.ToString(cluster.DBClusterParameterGroup), nil
+}
+
+func (g rdsClusterParameterGroups) copyGroup(ctx context.Context, source, target, description string) (bool, error) {
+	_, err := g.client.CopyDBClusterParameterGroup(ctx, &rds.CopyDBClusterParameterGroupInput{
+		SourceDBClusterParameterGroupIdentifier:  aws.String(source),
+		TargetDBClusterParameterGroupIdentifier:  aws.String(target),
+		TargetDBClusterParameterGroupDescription: aws.String(description),
+	})
+	var exists *rdstypes.DBParameterGroupAlreadyExistsFault
+	if errors.As(err, &exists) {
+		return true, nil
+	}
+	return false, err
+}
+
+func (g rdsClusterParameterGroups) values(ctx context.Context, group string, names []string) ([]parameterValueModel, error) {
+	values, err := rdsClusterParameterValues(ctx, g.client, group, names)
+	var notFound *rdstypes.DBParameterGroupNotFoundFault
+	if errors.As(err, &notFound) {
+		return nil, errParameterGroupNotFound
+	}
+	return values, err
+}
+
+func (g rdsClusterParameterGroups) modify(ctx context.Context, group string, changes []parameterChange) error {
+	_, err := g.client.ModifyDBClusterParameterGroup(ctx, &rds.ModifyDBClusterParameterGroupInput{
+		DBClusterParameterGroupName: aws.String(group),
+		Parameters:                  rdsParameters(changes),
+	})
+	return err
+}
+
+// rdsParameters converts the changes into RDS parameters
+func rdsParameters(changes []parameterChange) []rdstypes.Parameter {
+	parameters := make([]rdstypes.Parameter, 0, len(changes))
+	for _, change := range changes {
+		parameters = append(parameters, rdstypes.Parameter{
+			ParameterName:  aws.String(change.Name),
+			ParameterValue: aws.String(change.Value),
+			ApplyMethod:    rdstypes.ApplyMethod(change.ApplyMethod),
+		})
+	}
+	return parameters
+}
+
+// neptuneClusterParameterGroups manages the DB cluster parameter groups of Neptune clusters
+type neptuneClusterParameterGroups struct {
+	client *neptune.Client
+}
+
+func (g neptuneClusterParameterGroups) attachedGroup(ctx context.Context, identifier string) (string, error) {
+	result, err := g.client.DescribeDBClusters(ctx, &neptune.DescribeDBClustersInput{
+		DBClusterIdentifier: aws.String(identifier),
+	})
+	var notFound *neptunetypes.DBClusterNotFoundFault
+	if errors.As(err, &notFound) {
+		return "", errParameterGroupNotFound
+	}
+	if err != nil {
+		return "", err
+	}
+	if len(result.DBClusters) == 0 {
+		return "", errParameterGroupNotFound
+	}
+	return aws.ToString(result.DBClusters[0].DBClusterParameterGroup), nil
+}
+
+func (g neptuneClusterParameterGroups) copyGroup(ctx context.Context, source, target, description string) (bool, error) {
+	_, err := g.client.CopyDBClusterParameterGroup(ctx, &neptune.CopyDBClusterParameterGroupInput{
+		SourceDBClusterParameterGroupIdentifier:  aws.String(source),
+		TargetDBClusterParameterGroupIdentifier:  aws.String(target),
+		TargetDBClusterParameterGroupDescription: aws.String(description),
+	})
+	var exists *neptunetypes.DBParameterGroupAlreadyExistsFault
+	if errors.As(err, &exists) {
+		return true, nil
+	}
+	return false, err
+}
+
+func (g neptuneClusterParameterGroups) values(ctx context.Context, group string, names []string) ([]parameterValueModel, error) {
+	values, err := neptuneClusterParameterValues(ctx, g.client, group, names)
+	var notFound *neptunetypes.DBParameterGroupNotFoundFault
+	if errors.As(err, &notFound) {
+		return nil, errParameterGroupNotFound
+	}
+	return values, err
+}
+
+func (g neptuneClusterParameterGroups) modify(ctx context.Context, group string, changes []parameterChange) error {
+	parameters := make([]neptunetypes.Parameter, 0, len(changes))
+	for _, change := range changes {
+		parameters = append(parameters, neptunetypes.Parameter{
+			ParameterName:  aws.String(change.Name),
+			ParameterValue: aws.String(change.Value),
+			ApplyMethod:    neptunetypes.ApplyMethod(change.ApplyMethod),
+		})
+	}
+	_, err := g.client.ModifyDBClusterParameterGroup(ctx, &neptune.ModifyDBClusterParameterGroupInput{
+		DBClusterParameterGroupName: aws.String(group),
+		Parameters:                  parameters,
+	})
+	return err
+}
+
+// docdbClusterParameterGroups manages the cluster parameter groups of DocumentDB clusters
+type docdbClusterParameterGroups struct {
+	client *docdb.Client
+}
+
+func (g docdbClusterParameterGroups) attachedGroup(ctx context.Context, identifier string) (string, error) {
+	result, err := g.client.DescribeDBClusters(ctx, &docdb.DescribeDBClustersInput{
+		DBClusterIdentifier: aws.String(identifier),
+	})
+	var notFound *docdbtypes.DBClusterNotFoundFault
+	if errors.As(err, &notFound) {
+		return "", errParameterGroupNotFound
+	}
+	if err != nil {
+		return "", err
+	}
+	if len(result.DBClusters) == 0 {
+		return "", errParameterGroupNotFound
+	}
+	return aws.ToString(result.DBClusters[0].DBClusterParameterGroup), nil
+}
+
+func (g docdbClusterParameterGroups) copyGroup(ctx context.Context, source, target, description string) (bool, error) {
+	_, err := g.client.CopyDBClusterParameterGroup(ctx, &docdb.CopyDBClusterParameterGroupInput{
+		SourceDBClusterParameterGroupIdentifier:  aws.String(source),
+		TargetDBClusterParameterGroupIdentifier:  aws.String(target),
+		TargetDBClusterParameterGroupDescription: aws.String(description),
+	})
+	var exists *docdbtypes.DBParameterGroupAlreadyExistsFault
+	if errors.As(err, &exists) {
+		return true, nil
+	}
+	return false, err
+}
+
+func (g docdbClusterParameterGroups) values(ctx context.Context, group string, names []string) ([]parameterValueModel, error) {
+	values, err := docdbClusterParameterValues(ctx, g.client, group, names)
+	var notFound *docdbtypes.DBParameterGroupNotFoundFault
+	if errors.As(err, &notFound) {
+		return nil, errParameterGroupNotFound
+	}
+	return values, err
+}
+
+func (g docdbClusterParameterGroups) modify(ctx context.Context, group string, changes []parameterChange) error {
+	parameters := make([]docdbtypes.Parameter, 0, len(changes))
+	for _, change := range changes {
+		parameters = append(parameters, docdbtypes.Parameter{
+			ParameterName:  aws.String(change.Name),
+			ParameterValue: aws.String(change.Value),
+			ApplyMethod:    docdbtypes.ApplyMethod(change.ApplyMethod),
+		})
+	}
+	_, err := g.client.ModifyDBClusterParameterGroup(ctx, &docdb.ModifyDBClusterParameterGroupInput{
+		DBClusterParameterGroupName: aws.String(group),
+		Parameters:                  parameters,
+	})
+	return err
+}
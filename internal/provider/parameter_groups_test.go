@@ -0,0 +1,90 @@
+// Copyright (c) IBM Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+)
+
+func TestCheckParameterGroupType(t *testing.T) {
+	for _, groupType := range []string{parameterGroupRDSInstance, parameterGroupRDSCluster, parameterGroupNeptuneCluster, parameterGroupDocDBCluster} {
+		if err := checkParameterGroupType(groupType); err != nil {
+			t.Errorf("unexpected error for %s: %s", groupType, err)
+		}
+	}
+	if err := checkParameterGroupType("neptune_instance"); err == nil {
+		t.Error("expected an error for an unknown group type")
+	}
+}
+
+func TestIsDefaultParameterGroup(t *testing.T) {
+	if !isDefaultParameterGroup("default.postgres15") {
+		t.Error("expected default.postgres15 to be a default group")
+	}
+	if isDefaultParameterGroup("orders-postgres15-audit") {
+		t.Error("expected a custom group not to be a default group")
+	}
+}
+
+func TestParameterChanges(t *testing.T) {
+	current := []parameterValueModel{
+		parameterValue(aws.String("shared_preload_libraries"), aws.String("pg_stat_statements"), aws.String("engine-default"), aws.String("static"), aws.Bool(true)),
+		parameterValue(aws.String("pgaudit.log"), nil, aws.String("engine-default"), aws.String("dynamic"), aws.Bool(true)),
+		parameterValue(aws.String("pgaudit.role"), aws.String("rds_pgaudit"), aws.String("user"), aws.String("dynamic"), aws.Bool(true)),
+		parameterValue(aws.String("rds.extensions"), aws.String("pgaudit"), aws.String("system"), aws.String("static"), aws.Bool(false)),
+	}
+
+	changes, err := parameterChanges(map[string]string{
+		"shared_preload_libraries": "pg_stat_statements,pgaudit",
+		"pgaudit.log":              "ddl,role",
+		"pgaudit.role":             "rds_pgaudit",
+	}, current)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := []parameterChange{
+		{Name: "pgaudit.log", Value: "ddl,role", ApplyMethod: "immediate"},
+		{Name: "shared_preload_libraries", Value: "pg_stat_statements,pgaudit", ApplyMethod: parameterApplyPendingReboot},
+	}
+	if !slices.Equal(changes, want) {
+		t.Errorf("expected %+v, got %+v", want, changes)
+	}
+
+	if _, err := parameterChanges(map[string]string{"rds.extensions": "pgaudit,pgcrypto"}, current); err == nil {
+		t.Error("expected an error for a parameter that cannot be modified")
+	}
+	if _, err := parameterChanges(map[string]string{"server_audit_events": "CONNECT"}, current); err == nil {
+		t.Error("expected an error for a parameter missing from the group")
+	}
+}
+
+type recordingParameterGroups struct {
+	parameterGroupClient
+	batches [][]parameterChange
+}
+
+func (g *recordingParameterGroups) modify(ctx context.Context, group string, changes []parameterChange) error {
+	g.batches = append(g.batches, changes)
+	return nil
+}
+
+func TestApplyParameterChanges(t *testing.T) {
+	changes := make([]parameterChange, 45)
+	groups := &recordingParameterGroups{}
+	if err := applyParameterChanges(context.Background(), groups, "audit", changes); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var sizes []int
+	for _, batch := range groups.batches {
+		sizes = append(sizes, len(batch))
+	}
+	if want := []int{20, 20, 5}; !slices.Equal(sizes, want) {
+		t.Errorf("expected batches of %v, got %v", want, sizes)
+	}
+}
@@ -0,0 +1,202 @@
+// Copyright (c) IBM Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/docdb"
+	"github.com/aws/aws-sdk-go-v2/service/neptune"
+	"github.com/aws/aws-sdk-go-v2/service/rds"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// parameterValueModel describes one parameter of a DB or DB cluster parameter group
+type parameterValueModel struct {
+	Name         types.String `tfsdk:"name"`
+	Value        types.String `tfsdk:"value"`
+	Source       types.String `tfsdk:"source"`
+	ApplyType    types.String `tfsdk:"apply_type"`
+	IsModifiable types.Bool   `tfsdk:"is_modifiable"`
+}
+
+// parameterValueType is the object type of the elements of the parameters attribute
+var parameterValueType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"name":          types.StringType,
+		"value":         types.StringType,
+		"source":        types.StringType,
+		"apply_type":    types.StringType,
+		"is_modifiable": types.BoolType,
+	},
+}
+
+// parameterNamesAttribute is the optional filter of the parameter group data sources
+func parameterNamesAttribute(example string) schema.ListAttribute {
+	return schema.ListAttribute{
+		MarkdownDescription: "Names of the parameters to return in `parameters`, for example " + example + ". All parameters of the group are returned when unset",
+		ElementType:         types.StringType,
+		Optional:            true,
+	}
+}
+
+// parametersAttribute lists the parameter values of the parameter group data sources
+func parametersAttribute() schema.ListNestedAttribute {
+	return schema.ListNestedAttribute{
+		MarkdownDescription: "Parameters of the parameter group, restricted to `names` when set",
+		Computed:            true,
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"name": schema.StringAttribute{
+					MarkdownDescription: "Name of the parameter",
+					Computed:            true,
+				},
+				"value": schema.StringAttribute{
+					MarkdownDescription: "Value of the parameter, null when it is not set",
+					Computed:            true,
+				},
+				"source": schema.StringAttribute{
+					MarkdownDescription: "Source of the value: `engine-default`, `system` or `user`",
+					Computed:            true,
+				},
+				"apply_type": schema.StringAttribute{
+					MarkdownDescription: "`static` parameters need a reboot to take effect, `dynamic` ones apply immediately",
+					Computed:            true,
+				},
+				"is_modifiable": schema.BoolAttribute{
+					MarkdownDescription: "Whether the parameter can be modified",
+					Computed:            true,
+				},
+			},
+		},
+	}
+}
+
+// parameterNameFilter returns whether a parameter is selected by the names filter, an empty filter selects all
+func parameterNameFilter(names []string) func(name string) bool {
+	if len(names) == 0 {
+		return func(string) bool { return true }
+	}
+	selected := make(map[string]bool, len(names))
+	for _, name := range names {
+		selected[name] = true
+	}
+	return func(name string) bool { return selected[name] }
+}
+
+// parameterValue builds the model from the fields shared by the RDS, Neptune and DocumentDB parameter types
+func parameterValue(name, value, source, applyType *string, modifiable *bool) parameterValueModel {
+	return parameterValueModel{
+		Name:         types.StringValue(aws.ToString(name)),
+		Value:        types.StringPointerValue(value),
+		Source:       types.StringPointerValue(source),
+		ApplyType:    types.StringPointerValue(applyType),
+		IsModifiable: types.BoolPointerValue(modifiable),
+	}
+}
+
+// parameterNames reads the names filter of a parameter group data source
+func parameterNames(ctx context.Context, list types.List, diags *diag.Diagnostics) []string {
+	if list.IsNull() || list.IsUnknown() {
+		return nil
+	}
+	var names []string
+	diags.Append(list.ElementsAs(ctx, &names, false)...)
+	return names
+}
+
+// parametersListValue converts parameter values into the parameters attribute
+func parametersListValue(ctx context.Context, parameters []parameterValueModel, diags *diag.Diagnostics) types.List {
+	list, d := types.ListValueFrom(ctx, parameterValueType, parameters)
+	diags.Append(d...)
+	return list
+}
+
+// rdsParameterValues pages through the parameters of an RDS DB parameter group
+func rdsParameterValues(ctx context.Context, client *rds.Client, group string, names []string) ([]parameterValueModel, error) {
+	selected := parameterNameFilter(names)
+	parameters := []parameterValueModel{}
+	paginator := rds.NewDescribeDBParametersPaginator(client, &rds.DescribeDBParametersInput{
+		DBParameterGroupName: aws.String(group),
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range page.Parameters {
+			if selected(aws.ToString(p.ParameterName)) {
+				parameters = append(parameters, parameterValue(p.ParameterName, p.ParameterValue, p.Source, p.ApplyType, p.IsModifiable))
+			}
+		}
+	}
+	return parameters, nil
+}
+
+// rdsClusterParameterValues pages through the parameters of an Aurora DB cluster parameter group
+func rdsClusterParameterValues(ctx context.Context, client *rds.Client, group string, names []string) ([]parameterValueModel, error) {
+	selected := parameterNameFilter(names)
+	parameters := []parameterValueModel{}
+	paginator := rds.NewDescribeDBClusterParametersPaginator(client, &rds.DescribeDBClusterParametersInput{
+		DBClusterParameterGroupName: aws.String(group),
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range page.Parameters {
+			if selected(aws.ToString(p.ParameterName)) {
+				parameters = append(parameters, parameterValue(p.ParameterName, p.ParameterValue, p.Source, p.ApplyType, p.IsModifiable))
+			}
+		}
+	}
+	return parameters, nil
+}
+
+// neptuneClusterParameterValues pages through the parameters of a Neptune DB cluster parameter group
+func neptuneClusterParameterValues(ctx context.Context, client *neptune.Client, group string, names []string) ([]parameterValueModel, error) {
+	selected := parameterNameFilter(names)
+	parameters := []parameterValueModel{}
+	paginator := neptune.NewDescribeDBClusterParametersPaginator(client, &neptune.DescribeDBClusterParametersInput{
+		DBClusterParameterGroupName: aws.String(group),
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range page.Parameters {
+			if selected(aws.ToString(p.ParameterName)) {
+				parameters = append(parameters, parameterValue(p.ParameterName, p.ParameterValue, p.Source, p.ApplyType, p.IsModifiable))
+			}
+		}
+	}
+	return parameters, nil
+}
+
+// docdbClusterParameterValues pages through the parameters of a DocumentDB cluster parameter group
+func docdbClusterParameterValues(ctx context.Context, client *docdb.Client, group string, names []string) ([]parameterValueModel, error) {
+	selected := parameterNameFilter(names)
+	parameters := []parameterValueModel{}
+	paginator := docdb.NewDescribeDBClusterParametersPaginator(client, &docdb.DescribeDBClusterParametersInput{
+		DBClusterParameterGroupName: aws.String(group),
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range page.Parameters {
+			if selected(aws.ToString(p.ParameterName)) {
+				parameters = append(parameters, parameterValue(p.ParameterName, p.ParameterValue, p.Source, p.ApplyType, p.IsModifiable))
+			}
+		}
+	}
+	return parameters, nil
+}
@@ -0,0 +1,59 @@
+// Copyright (c) IBM Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+func TestParameterNameFilter(t *testing.T) {
+	all := parameterNameFilter(nil)
+	if !all("log_connections") {
+		t.Error("expected an empty filter to select every parameter")
+	}
+
+	selected := parameterNameFilter([]string{"shared_preload_libraries", "pgaudit.log"})
+	if !selected("pgaudit.log") {
+		t.Error("expected pgaudit.log to be selected")
+	}
+	if selected("log_connections") {
+		t.Error("expected log_connections not to be selected")
+	}
+}
+
+func TestParameterValue(t *testing.T) {
+	value := parameterValue(aws.String("pgaudit.log"), nil, aws.String("engine-default"), aws.String("dynamic"), aws.Bool(true))
+	if value.Name.ValueString() != "pgaudit.log" {
+		t.Errorf("expected pgaudit.log, got %s", value.Name)
+	}
+	if !value.Value.IsNull() {
+		t.Errorf("expected a null value for an unset parameter, got %s", value.Value)
+	}
+	if !value.IsModifiable.ValueBool() {
+		t.Error("expected the parameter to be modifiable")
+	}
+}
+
+func TestParametersListValue(t *testing.T) {
+	var diags diag.Diagnostics
+	parameters := []parameterValueModel{
+		parameterValue(aws.String("audit_logs"), aws.String("enabled"), aws.String("user"), aws.String("dynamic"), aws.Bool(true)),
+	}
+
+	list := parametersListValue(context.Background(), parameters, &diags)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if len(list.Elements()) != 1 {
+		t.Errorf("expected one parameter, got %d", len(list.Elements()))
+	}
+
+	if empty := parametersListValue(context.Background(), []parameterValueModel{}, &diags); empty.IsNull() || len(empty.Elements()) != 0 {
+		t.Errorf("expected an empty list, got %s", empty)
+	}
+}
@@ -0,0 +1,107 @@
+// Copyright (c) IBM Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	docdbtypes "github.com/aws/aws-sdk-go-v2/service/docdb/types"
+	neptunetypes "github.com/aws/aws-sdk-go-v2/service/neptune/types"
+	"github.com/aws/aws-sdk-go-v2/service/rds"
+	rdstypes "github.com/aws/aws-sdk-go-v2/service/rds/types"
+)
+
+// parameterApplyPendingReboot is the parameter group status reported while changed static
+// parameters wait for the next reboot
+const parameterApplyPendingReboot = "pending-reboot"
+
+// pendingReboot summarises what an instance or cluster is waiting for before a reboot
+type pendingReboot struct {
+	// Modifications is set when pending modified values are reported
+	Modifications bool
+	// Parameters is set when a parameter group is in pending-reboot state
+	Parameters bool
+}
+
+// required reports whether a reboot would apply pending changes. Only parameter groups in
+// pending-reboot state count: pending modified values are applied in the maintenance window
+// or by a modify call, so a reboot does not clear them.
+func (p pendingReboot) required() bool {
+	return p.Parameters
+}
+
+// rdsInstancePendingReboot inspects the pending modified values and parameter groups of an RDS instance
+func rdsInstancePendingReboot(instance rdstypes.DBInstance) pendingReboot {
+	pending := pendingReboot{
+		Modifications: instance.PendingModifiedValues != nil && !reflect.ValueOf(*instance.PendingModifiedValues).IsZero(),
+	}
+	for _, group := range instance.DBParameterGroups {
+		if aws.ToString(group.ParameterApplyStatus) == parameterApplyPendingReboot {
+			pending.Parameters = true
+		}
+	}
+	return pending
+}
+
+// auroraClusterPendingReboot inspects an Aurora cluster, the cluster parameter group status of
+// its members and the pending state of its instances
+func auroraClusterPendingReboot(cluster rdstypes.DBCluster, instances []rdstypes.DBInstance) pendingReboot {
+	pending := pendingReboot{
+		Modifications: cluster.PendingModifiedValues != nil && !reflect.ValueOf(*cluster.PendingModifiedValues).IsZero(),
+	}
+	for _, member := range cluster.DBClusterMembers {
+		if aws.ToString(member.DBClusterParameterGroupStatus) == parameterApplyPendingReboot {
+			pending.Parameters = true
+		}
+	}
+	for _, instance := range instances {
+		instancePending := rdsInstancePendingReboot(instance)
+		pending.Modifications = pending.Modifications || instancePending.Modifications
+		pending.Parameters = pending.Parameters || instancePending.Parameters
+	}
+	return pending
+}
+
+// neptuneClusterPendingReboot reports whether a member of a Neptune cluster has its cluster
+// parameter group in pending-reboot state
+func neptuneClusterPendingReboot(cluster neptunetypes.DBCluster) bool {
+	for _, member := range cluster.DBClusterMembers {
+		if aws.ToString(member.DBClusterParameterGroupStatus) == parameterApplyPendingReboot {
+			return true
+		}
+	}
+	return false
+}
+
+// docdbClusterPendingReboot reports whether a member of a DocumentDB cluster has its cluster
+// parameter group in pending-reboot state
+func docdbClusterPendingReboot(cluster docdbtypes.DBCluster) bool {
+	for _, member := range cluster.DBClusterMembers {
+		if aws.ToString(member.DBClusterParameterGroupStatus) == parameterApplyPendingReboot {
+			return true
+		}
+	}
+	return false
+}
+
+// describeAuroraClusterInstances returns the instances that belong to an Aurora cluster
+func describeAuroraClusterInstances(ctx context.Context, client *rds.Client, clusterID string) ([]rdstypes.DBInstance, error) {
+	var instances []rdstypes.DBInstance
+	paginator := rds.NewDescribeDBInstancesPaginator(client, &rds.DescribeDBInstancesInput{
+		Filters: []rdstypes.Filter{{
+			Name:   aws.String("db-cluster-id"),
+			Values: []string{clusterID},
+		}},
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		instances = append(instances, page.DBInstances...)
+	}
+	return instances, nil
+}
@@ -0,0 +1,100 @@
+// Copyright (c) IBM Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	docdbtypes "github.com/aws/aws-sdk-go-v2/service/docdb/types"
+	neptunetypes "github.com/aws/aws-sdk-go-v2/service/neptune/types"
+	rdstypes "github.com/aws/aws-sdk-go-v2/service/rds/types"
+)
+
+func TestRDSInstancePendingReboot(t *testing.T) {
+	instance := rdstypes.DBInstance{
+		DBParameterGroups: []rdstypes.DBParameterGroupStatus{
+			{DBParameterGroupName: aws.String("audit"), ParameterApplyStatus: aws.String("in-sync")},
+		},
+		PendingModifiedValues: &rdstypes.PendingModifiedValues{},
+	}
+	if got := rdsInstancePendingReboot(instance); got.required() || got.Modifications {
+		t.Errorf("expected no reboot for an in-sync instance, got %+v", got)
+	}
+
+	instance.DBParameterGroups = append(instance.DBParameterGroups, rdstypes.DBParameterGroupStatus{
+		DBParameterGroupName: aws.String("audit-v2"),
+		ParameterApplyStatus: aws.String(parameterApplyPendingReboot),
+	})
+	if got := rdsInstancePendingReboot(instance); !got.required() {
+		t.Errorf("expected a reboot for a pending-reboot parameter group, got %+v", got)
+	}
+
+	instance.DBParameterGroups = nil
+	instance.PendingModifiedValues = &rdstypes.PendingModifiedValues{DBInstanceClass: aws.String("db.r6g.large")}
+	got := rdsInstancePendingReboot(instance)
+	if !got.Modifications {
+		t.Errorf("expected pending modified values to be reported, got %+v", got)
+	}
+	if got.required() {
+		t.Errorf("expected no reboot for a change waiting for the maintenance window, got %+v", got)
+	}
+}
+
+func TestAuroraClusterPendingReboot(t *testing.T) {
+	cluster := rdstypes.DBCluster{
+		DBClusterMembers: []rdstypes.DBClusterMember{
+			{DBInstanceIdentifier: aws.String("writer"), DBClusterParameterGroupStatus: aws.String("in-sync")},
+			{DBInstanceIdentifier: aws.String("reader"), DBClusterParameterGroupStatus: aws.String("in-sync")},
+		},
+	}
+	instances := []rdstypes.DBInstance{{DBInstanceIdentifier: aws.String("writer")}, {DBInstanceIdentifier: aws.String("reader")}}
+	if got := auroraClusterPendingReboot(cluster, instances); got.required() {
+		t.Errorf("expected no reboot for an in-sync cluster, got %+v", got)
+	}
+
+	cluster.DBClusterMembers[1].DBClusterParameterGroupStatus = aws.String(parameterApplyPendingReboot)
+	if got := auroraClusterPendingReboot(cluster, instances); !got.required() {
+		t.Errorf("expected a reboot for a pending-reboot cluster member, got %+v", got)
+	}
+
+	cluster.DBClusterMembers[1].DBClusterParameterGroupStatus = aws.String("in-sync")
+	instances[0].DBParameterGroups = []rdstypes.DBParameterGroupStatus{{ParameterApplyStatus: aws.String(parameterApplyPendingReboot)}}
+	if got := auroraClusterPendingReboot(cluster, instances); !got.required() {
+		t.Errorf("expected a reboot for a pending-reboot instance parameter group, got %+v", got)
+	}
+}
+
+func TestNeptuneClusterPendingReboot(t *testing.T) {
+	cluster := neptunetypes.DBCluster{
+		DBClusterMembers: []neptunetypes.DBClusterMember{
+			{DBInstanceIdentifier: aws.String("writer"), DBClusterParameterGroupStatus: aws.String("in-sync")},
+		},
+	}
+	if neptuneClusterPendingReboot(cluster) {
+		t.Error("expected no reboot for an in-sync cluster")
+	}
+
+	cluster.DBClusterMembers[0].DBClusterParameterGroupStatus = aws.String(parameterApplyPendingReboot)
+	if !neptuneClusterPendingReboot(cluster) {
+		t.Error("expected a reboot for a pending-reboot cluster member")
+	}
+}
+
+func TestDocDBClusterPendingReboot(t *testing.T) {
+	cluster := docdbtypes.DBCluster{
+		DBClusterMembers: []docdbtypes.DBClusterMember{
+			{DBInstanceIdentifier: aws.String("writer"), DBClusterParameterGroupStatus: aws.String("in-sync")},
+			{DBInstanceIdentifier: aws.String("reader"), DBClusterParameterGroupStatus: aws.String(parameterApplyPendingReboot)},
+		},
+	}
+	if !docdbClusterPendingReboot(cluster) {
+		t.Error("expected a reboot for a pending-reboot cluster member")
+	}
+
+	cluster.DBClusterMembers = cluster.DBClusterMembers[:1]
+	if docdbClusterPendingReboot(cluster) {
+		t.Error("expected no reboot for an in-sync cluster")
+	}
+}
@@ -0,0 +1,403 @@
+// Copyright (c) IBM Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+	dsschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	rschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	defaultPostgresPort    = "5432"
+	defaultPostgresSSLMode = "disable"
+
+	// rdsIAMAuthTokenExpiry is the lifetime of an RDS IAM auth token, which is only checked when connecting
+	rdsIAMAuthTokenExpiry = 15 * time.Minute
+
+	// emptyPayloadHash is the SHA-256 of an empty body, used when presigning the IAM auth token
+	emptyPayloadHash = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+)
+
+// postgresConnectionModel maps the connection attributes shared by the PostgreSQL resources and data sources.
+// It is embedded by value in their models.
+type postgresConnectionModel struct {
+	Host     types.String `tfsdk:"host"`
+	Port     types.String `tfsdk:"port"`
+	Username types.String `tfsdk:"username"`
+	Password types.String `tfsdk:"password"`
+	DBName   types.String `tfsdk:"db_name"`
+	SSLMode  types.String `tfsdk:"ssl_mode"`
+
+	SSLRootCert     types.String `tfsdk:"sslrootcert"`
+	SSLCert         types.String `tfsdk:"sslcert"`
+	SSLKey          types.String `tfsdk:"sslkey"`
+	ConnectTimeout  types.Int64  `tfsdk:"connect_timeout"`
+	ApplicationName types.String `tfsdk:"application_name"`
+	IAMAuth         types.Bool   `tfsdk:"iam_auth"`
+	Region          types.String `tfsdk:"region"`
+
+	PasswordSecretARN    types.String `tfsdk:"password_secret_arn"`
+	CredentialsSecretARN types.String `tfsdk:"credentials_secret_arn"`
+}
+
+// postgresCredentials holds the connection values read from a Secrets Manager secret, in the JSON
+// layout used by RDS managed secrets
+type postgresCredentials struct {
+	Username string      `json:"username"`
+	Password string      `json:"password"`
+	Host     string      `json:"host"`
+	Port     json.Number `json:"port"`
+	DBName   string      `json:"dbname"`
+}
+
+// Descriptions of the connection options, shared by the data source and resource schemas
+const (
+	postgresHostDescription            = "PostgreSQL server hostname or IP address. Required unless set in credentials_secret_arn."
+	postgresUsernameDescription        = "PostgreSQL username. Required unless set in credentials_secret_arn or password_secret_arn."
+	postgresDBNameDescription          = "PostgreSQL database name. Required unless set in credentials_secret_arn."
+	postgresPasswordDescription        = "PostgreSQL password. Required unless iam_auth, password_secret_arn or credentials_secret_arn is set."
+	postgresSSLRootCertDescription     = "Path to the CA bundle used to verify the server certificate, for example the RDS global bundle with ssl_mode verify-full."
+	postgresSSLCertDescription         = "Path to the client certificate."
+	postgresSSLKeyDescription          = "Path to the client certificate key."
+	postgresConnectTimeoutDescription  = "Maximum time to wait for the connection, in seconds."
+	postgresApplicationNameDescription = "Application name reported to the server in pg_stat_activity."
+	postgresIAMAuthDescription         = "Authenticate with an RDS IAM auth token generated from the provider credentials instead of a static password. " +
+		"Requires ssl_mode require, verify-ca or verify-full."
+	postgresRegionDescription            = "AWS region used to sign the IAM auth token. Defaults to the provider region."
+	postgresPasswordSecretARNDescription = "ARN of a Secrets Manager secret holding the password, either as plain text or as JSON with a password key, " +
+		"such as an RDS managed master user secret."
+	postgresCredentialsSecretARNDescription = "ARN of a Secrets Manager secret holding the JSON object {username, password, host, port, dbname}. " +
+		"Attributes set in the configuration take precedence over the secret values. The secret port is only used when port is not set."
+)
+
+// postgresConnectionDataSourceAttributes returns the connection attributes for PostgreSQL data sources
+func postgresConnectionDataSourceAttributes() map[string]dsschema.Attribute {
+	return map[string]dsschema.Attribute{
+		"host": dsschema.StringAttribute{
+			Description: postgresHostDescription,
+			Optional:    true,
+		},
+		"port": dsschema.StringAttribute{
+			Description: "PostgreSQL server port.",
+			Optional:    true,
+			Computed:    true,
+		},
+		"username": dsschema.StringAttribute{
+			Description: postgresUsernameDescription,
+			Optional:    true,
+		},
+		"password": dsschema.StringAttribute{
+			Description: postgresPasswordDescription,
+			Optional:    true,
+			Sensitive:   true,
+		},
+		"db_name": dsschema.StringAttribute{
+			Description: postgresDBNameDescription,
+			Optional:    true,
+		},
+		"ssl_mode": dsschema.StringAttribute{
+			Description: "PostgreSQL SSL mode (disable, require, verify-ca, verify-full).",
+			Optional:    true,
+			Computed:    true,
+		},
+		"sslrootcert":      dsschema.StringAttribute{Description: postgresSSLRootCertDescription, Optional: true},
+		"sslcert":          dsschema.StringAttribute{Description: postgresSSLCertDescription, Optional: true},
+		"sslkey":           dsschema.StringAttribute{Description: postgresSSLKeyDescription, Optional: true},
+		"connect_timeout":  dsschema.Int64Attribute{Description: postgresConnectTimeoutDescription, Optional: true},
+		"application_name": dsschema.StringAttribute{Description: postgresApplicationNameDescription, Optional: true},
+		"iam_auth":         dsschema.BoolAttribute{Description: postgresIAMAuthDescription, Optional: true},
+		"region":           dsschema.StringAttribute{Description: postgresRegionDescription, Optional: true},
+		"password_secret_arn": dsschema.StringAttribute{
+			Description: postgresPasswordSecretARNDescription,
+			Optional:    true,
+		},
+		"credentials_secret_arn": dsschema.StringAttribute{
+			Description: postgresCredentialsSecretARNDescription,
+			Optional:    true,
+		},
+	}
+}
+
+// postgresConnectionResourceAttributes returns the connection attributes for PostgreSQL resources
+func postgresConnectionResourceAttributes() map[string]rschema.Attribute {
+	return map[string]rschema.Attribute{
+		"host": rschema.StringAttribute{
+			Description: postgresHostDescription,
+			Optional:    true,
+		},
+		"port": rschema.StringAttribute{
+			Description: "PostgreSQL server port. Defaults to the port in credentials_secret_arn, or 5432.",
+			Optional:    true,
+			Computed:    true,
+		},
+		"username": rschema.StringAttribute{
+			Description: postgresUsernameDescription,
+			Optional:    true,
+		},
+		"password": rschema.StringAttribute{
+			Description: postgresPasswordDescription,
+			Optional:    true,
+			Sensitive:   true,
+		},
+		"db_name": rschema.StringAttribute{
+			Description: postgresDBNameDescription,
+			Optional:    true,
+		},
+		"ssl_mode": rschema.StringAttribute{
+			Description: "PostgreSQL SSL mode (disable, require, verify-ca, verify-full).",
+			Optional:    true,
+			Computed:    true,
+			Default:     stringdefault.StaticString(defaultPostgresSSLMode),
+		},
+		"sslrootcert":      rschema.StringAttribute{Description: postgresSSLRootCertDescription, Optional: true},
+		"sslcert":          rschema.StringAttribute{Description: postgresSSLCertDescription, Optional: true},
+		"sslkey":           rschema.StringAttribute{Description: postgresSSLKeyDescription, Optional: true},
+		"connect_timeout":  rschema.Int64Attribute{Description: postgresConnectTimeoutDescription, Optional: true},
+		"application_name": rschema.StringAttribute{Description: postgresApplicationNameDescription, Optional: true},
+		"iam_auth":         rschema.BoolAttribute{Description: postgresIAMAuthDescription, Optional: true},
+		"region":           rschema.StringAttribute{Description: postgresRegionDescription, Optional: true},
+		"password_secret_arn": rschema.StringAttribute{
+			Description: postgresPasswordSecretARNDescription,
+			Optional:    true,
+		},
+		"credentials_secret_arn": rschema.StringAttribute{
+			Description: postgresCredentialsSecretARNDescription,
+			Optional:    true,
+		},
+	}
+}
+
+// setDefaults fills in the port and SSL mode when they are not configured
+func (m *postgresConnectionModel) setDefaults() {
+	if m.Port.IsNull() || m.Port.IsUnknown() || m.Port.ValueString() == "" {
+		m.Port = types.StringValue(defaultPostgresPort)
+	}
+
+	if m.SSLMode.IsNull() || m.SSLMode.IsUnknown() || m.SSLMode.ValueString() == "" {
+		m.SSLMode = types.StringValue(defaultPostgresSSLMode)
+	}
+}
+
+// connect opens a connection to the configured PostgreSQL database. The provider data is only
+// needed to read credential secrets or generate an IAM auth token and may be nil otherwise.
+func (m *postgresConnectionModel) connect(ctx context.Context, pd *providerData) (*pgx.Conn, error) {
+	creds, err := m.resolveCredentials(ctx, pd)
+	if err != nil {
+		return nil, err
+	}
+
+	// The port is taken from the configuration, then the credentials secret, then the default
+	if m.Port.IsNull() || m.Port.IsUnknown() || m.Port.ValueString() == "" {
+		if creds.Port != "" {
+			m.Port = types.StringValue(creds.Port.String())
+		}
+	}
+	m.setDefaults()
+
+	if creds.Host == "" {
+		return nil, errors.New("host is required unless it is set in credentials_secret_arn")
+	}
+	if creds.Username == "" {
+		return nil, errors.New("username is required unless it is set in a credentials secret")
+	}
+	if creds.DBName == "" {
+		return nil, errors.New("db_name is required unless it is set in credentials_secret_arn")
+	}
+
+	if m.IAMAuth.ValueBool() {
+		if m.SSLMode.ValueString() == "disable" || m.SSLMode.ValueString() == "allow" {
+			return nil, fmt.Errorf("iam_auth requires ssl_mode require, verify-ca or verify-full, got %s", m.SSLMode.ValueString())
+		}
+		if pd == nil {
+			return nil, errors.New("iam_auth requires a configured provider")
+		}
+
+		cfg := pd.regionalConfig(m.Region.ValueString())
+		token, err := buildRDSIAMAuthToken(ctx, cfg.Credentials, net.JoinHostPort(creds.Host, m.Port.ValueString()),
+			cfg.Region, creds.Username, time.Now())
+		if err != nil {
+			return nil, fmt.Errorf("error generating IAM auth token: %w", err)
+		}
+		creds.Password = token
+	} else if creds.Password == "" {
+		return nil, errors.New("password is required unless iam_auth, password_secret_arn or credentials_secret_arn is set")
+	}
+
+	return pgx.Connect(ctx, m.connString(creds))
+}
+
+// resolveCredentials merges the configured connection values with the referenced secrets.
+// Configured values take precedence.
+func (m *postgresConnectionModel) resolveCredentials(ctx context.Context, pd *providerData) (postgresCredentials, error) {
+	creds := postgresCredentials{}
+
+	if arn := m.CredentialsSecretARN.ValueString(); arn != "" {
+		secret, err := m.readSecret(ctx, pd, arn)
+		if err != nil {
+			return creds, err
+		}
+		if creds, err = parsePostgresCredentials(secret); err != nil {
+			return creds, fmt.Errorf("error parsing secret %s: %w", arn, err)
+		}
+	}
+
+	if arn := m.PasswordSecretARN.ValueString(); arn != "" {
+		secret, err := m.readSecret(ctx, pd, arn)
+		if err != nil {
+			return creds, err
+		}
+
+		// RDS managed master user secrets hold {username, password}, other secrets may be plain text
+		if parsed, err := parsePostgresCredentials(secret); err == nil && parsed.Password != "" {
+			creds.Password = parsed.Password
+			if creds.Username == "" {
+				creds.Username = parsed.Username
+			}
+		} else {
+			creds.Password = secret
+		}
+	}
+
+	if !m.Host.IsNull() {
+		creds.Host = m.Host.ValueString()
+	}
+	if !m.Username.IsNull() {
+		creds.Username = m.Username.ValueString()
+	}
+	if !m.Password.IsNull() {
+		creds.Password = m.Password.ValueString()
+	}
+	if !m.DBName.IsNull() {
+		creds.DBName = m.DBName.ValueString()
+	}
+	return creds, nil
+}
+
+// readSecret returns the string value of a Secrets Manager secret. The client region is taken
+// from the ARN so that secrets can live outside the provider region.
+func (m *postgresConnectionModel) readSecret(ctx context.Context, pd *providerData, secretARN string) (string, error) {
+	if pd == nil {
+		return "", errors.New("reading credentials from Secrets Manager requires a configured provider")
+	}
+
+	region := m.Region.ValueString()
+	if parsed, err := arn.Parse(secretARN); err == nil {
+		region = parsed.Region
+	}
+
+	tflog.Debug(ctx, "Reading PostgreSQL credentials from Secrets Manager", map[string]interface{}{"secret_arn": secretARN})
+	out, err := pd.secretsmanagerClient(region).GetSecretValue(ctx, &secretsmanager.GetSecretValueInput{
+		SecretId: aws.String(secretARN),
+	})
+	if err != nil {
+		return "", fmt.Errorf("error reading secret %s: %w", secretARN, err)
+	}
+	if out.SecretString == nil {
+		return "", fmt.Errorf("secret %s has no string value", secretARN)
+	}
+	return *out.SecretString, nil
+}
+
+// parsePostgresCredentials decodes a JSON credentials secret. The port may be a number or a string.
+func parsePostgresCredentials(secret string) (postgresCredentials, error) {
+	var creds postgresCredentials
+	decoder := json.NewDecoder(strings.NewReader(secret))
+	decoder.UseNumber()
+	if err := decoder.Decode(&creds); err != nil {
+		return postgresCredentials{}, err
+	}
+	return creds, nil
+}
+
+// connString builds the connection URL, escaping the credentials and options
+func (m *postgresConnectionModel) connString(creds postgresCredentials) string {
+	query := url.Values{}
+	query.Set("sslmode", m.SSLMode.ValueString())
+	if !m.SSLRootCert.IsNull() {
+		query.Set("sslrootcert", m.SSLRootCert.ValueString())
+	}
+	if !m.SSLCert.IsNull() {
+		query.Set("sslcert", m.SSLCert.ValueString())
+	}
+	if !m.SSLKey.IsNull() {
+		query.Set("sslkey", m.SSLKey.ValueString())
+	}
+	if !m.ConnectTimeout.IsNull() {
+		query.Set("connect_timeout", strconv.FormatInt(m.ConnectTimeout.ValueInt64(), 10))
+	}
+	if !m.ApplicationName.IsNull() {
+		query.Set("application_name", m.ApplicationName.ValueString())
+	}
+
+	connURL := url.URL{
+		Scheme:   "postgres",
+		User:     url.UserPassword(creds.Username, creds.Password),
+		Host:     net.JoinHostPort(creds.Host, m.Port.ValueString()),
+		Path:     "/" + creds.DBName,
+		RawQuery: query.Encode(),
+	}
+	return connURL.String()
+}
+
+// buildRDSIAMAuthToken presigns an rds-db:connect request for the user, the same way the RDS auth
+// utility of the AWS SDK does. The token is the presigned URL without its scheme.
+func buildRDSIAMAuthToken(ctx context.Context, credentials aws.CredentialsProvider, endpoint, region, user string, now time.Time) (string, error) {
+	if credentials == nil {
+		return "", errors.New("no AWS credentials available")
+	}
+	if region == "" {
+		return "", errors.New("no AWS region available")
+	}
+
+	creds, err := credentials.Retrieve(ctx)
+	if err != nil {
+		return "", fmt.Errorf("error retrieving AWS credentials: %w", err)
+	}
+
+	query := url.Values{
+		"Action":        {"connect"},
+		"DBUser":        {user},
+		"X-Amz-Expires": {strconv.Itoa(int(rdsIAMAuthTokenExpiry.Seconds()))},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://"+endpoint+"/?"+query.Encode(), nil)
+	if err != nil {
+		return "", err
+	}
+
+	signedURL, _, err := v4.NewSigner().PresignHTTP(ctx, creds, req, emptyPayloadHash, "rds-db", region, now.UTC())
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimPrefix(signedURL, "https://"), nil
+}
+
+// quotePostgresIdentifier quotes a role, database or schema name for use in a SQL statement
+func quotePostgresIdentifier(name string) string {
+	return pgx.Identifier{name}.Sanitize()
+}
+
+// quotePostgresLiteral quotes a string literal for statements that do not accept bind parameters,
+// such as ALTER ... SET and CREATE ROLE ... PASSWORD
+func quotePostgresLiteral(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", "''") + "'"
+}
@@ -0,0 +1,160 @@
+// Copyright (c) IBM Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jackc/pgx/v5"
+)
+
+func TestPostgresConnString(t *testing.T) {
+	m := postgresConnectionModel{
+		SSLMode:         types.StringValue("disable"),
+		ConnectTimeout:  types.Int64Value(10),
+		ApplicationName: types.StringValue("gdp"),
+	}
+	m.setDefaults()
+
+	password := "p@ss/w%rd:1"
+	creds := postgresCredentials{Host: "db.example.com", Username: "guardium@corp", Password: password, DBName: "orders"}
+	config, err := pgx.ParseConfig(m.connString(creds))
+	if err != nil {
+		t.Fatalf("unexpected parse error: %s", err)
+	}
+
+	if config.User != "guardium@corp" || config.Password != password {
+		t.Errorf("credentials not preserved, got user %q password %q", config.User, config.Password)
+	}
+	if config.Host != "db.example.com" || config.Port != 5432 || config.Database != "orders" {
+		t.Errorf("unexpected target %s:%d/%s", config.Host, config.Port, config.Database)
+	}
+	if config.ConnectTimeout != 10*time.Second {
+		t.Errorf("expected a 10s connect timeout, got %s", config.ConnectTimeout)
+	}
+	if config.RuntimeParams["application_name"] != "gdp" {
+		t.Errorf("expected application_name gdp, got %q", config.RuntimeParams["application_name"])
+	}
+
+	// The CA bundle is only read by pgx when connecting, so check the URL itself
+	m.SSLMode = types.StringValue("verify-full")
+	m.SSLRootCert = types.StringValue("/etc/ssl/rds global.pem")
+	parsed, err := url.Parse(m.connString(creds))
+	if err != nil {
+		t.Fatalf("unexpected parse error: %s", err)
+	}
+	if got := parsed.Query().Get("sslrootcert"); got != "/etc/ssl/rds global.pem" {
+		t.Errorf("expected sslrootcert to be preserved, got %q", got)
+	}
+}
+
+func TestPostgresConnectRequiresPassword(t *testing.T) {
+	m := postgresConnectionModel{
+		Host:     types.StringValue("localhost"),
+		Username: types.StringValue("postgres"),
+		DBName:   types.StringValue("postgres"),
+	}
+	if _, err := m.connect(context.Background(), nil); err == nil || !strings.Contains(err.Error(), "password is required") {
+		t.Errorf("expected a missing password error, got %v", err)
+	}
+
+	m.IAMAuth = types.BoolValue(true)
+	if _, err := m.connect(context.Background(), nil); err == nil || !strings.Contains(err.Error(), "ssl_mode") {
+		t.Errorf("expected an ssl_mode error, got %v", err)
+	}
+}
+
+func TestPostgresConnectRequiresHost(t *testing.T) {
+	m := postgresConnectionModel{Username: types.StringValue("postgres"), Password: types.StringValue("secret")}
+	if _, err := m.connect(context.Background(), nil); err == nil || !strings.Contains(err.Error(), "host is required") {
+		t.Errorf("expected a missing host error, got %v", err)
+	}
+
+	m.CredentialsSecretARN = types.StringValue("arn:aws:secretsmanager:us-east-1:123456789012:secret:db")
+	if _, err := m.connect(context.Background(), nil); err == nil || !strings.Contains(err.Error(), "configured provider") {
+		t.Errorf("expected an unconfigured provider error, got %v", err)
+	}
+}
+
+func TestParsePostgresCredentials(t *testing.T) {
+	for _, secret := range []string{
+		`{"username":"admin","password":"p@ss","host":"db.example.com","port":5433,"dbname":"orders","engine":"postgres"}`,
+		`{"username":"admin","password":"p@ss","host":"db.example.com","port":"5433","dbname":"orders"}`,
+	} {
+		creds, err := parsePostgresCredentials(secret)
+		if err != nil {
+			t.Fatalf("unexpected error for %s: %s", secret, err)
+		}
+		want := postgresCredentials{Username: "admin", Password: "p@ss", Host: "db.example.com", Port: "5433", DBName: "orders"}
+		if creds != want {
+			t.Errorf("expected %+v, got %+v", want, creds)
+		}
+	}
+
+	if _, err := parsePostgresCredentials("plain-text-password"); err == nil {
+		t.Error("expected an error for a plain text secret")
+	}
+}
+
+func TestResolveCredentialsConfigured(t *testing.T) {
+	m := postgresConnectionModel{
+		Host:     types.StringValue("db.example.com"),
+		Username: types.StringValue("admin"),
+		Password: types.StringValue("secret"),
+		DBName:   types.StringValue("orders"),
+	}
+	creds, err := m.resolveCredentials(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := postgresCredentials{Username: "admin", Password: "secret", Host: "db.example.com", DBName: "orders"}
+	if creds != want {
+		t.Errorf("expected %+v, got %+v", want, creds)
+	}
+}
+
+func TestBuildRDSIAMAuthToken(t *testing.T) {
+	credentials := aws.CredentialsProviderFunc(func(context.Context) (aws.Credentials, error) {
+		return aws.Credentials{AccessKeyID: "AKIDEXAMPLE", SecretAccessKey: "secret"}, nil
+	})
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	token, err := buildRDSIAMAuthToken(context.Background(), credentials, "db.example.com:5432", "us-east-1", "iam user", now)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !strings.HasPrefix(token, "db.example.com:5432/?") {
+		t.Fatalf("unexpected token prefix: %s", token)
+	}
+
+	query, err := url.ParseQuery(strings.SplitN(token, "?", 2)[1])
+	if err != nil {
+		t.Fatalf("unexpected query: %s", err)
+	}
+	checks := map[string]string{
+		"Action":           "connect",
+		"DBUser":           "iam user",
+		"X-Amz-Expires":    "900",
+		"X-Amz-Date":       "20260102T030405Z",
+		"X-Amz-Credential": "AKIDEXAMPLE/20260102/us-east-1/rds-db/aws4_request",
+	}
+	for key, want := range checks {
+		if got := query.Get(key); got != want {
+			t.Errorf("expected %s=%q, got %q", key, want, got)
+		}
+	}
+	if query.Get("X-Amz-Signature") == "" {
+		t.Error("expected a signature")
+	}
+
+	if _, err := buildRDSIAMAuthToken(context.Background(), nil, "db:5432", "us-east-1", "u", now); err == nil {
+		t.Error("expected an error without credentials")
+	}
+}
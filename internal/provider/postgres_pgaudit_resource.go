@@ -0,0 +1,438 @@
+// Copyright (c) IBM Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	pgauditScopeDatabase = "database"
+	pgauditScopeRole     = "role"
+
+	defaultPgauditRoleName = "rds_pgaudit"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var _ resource.Resource = &postgresPgauditResource{}
+var _ resource.ResourceWithConfigure = &postgresPgauditResource{}
+
+// NewPostgresPgauditResource is a helper function to simplify the provider implementation.
+func NewPostgresPgauditResource() resource.Resource {
+	return &postgresPgauditResource{}
+}
+
+// postgresPgauditResource is the resource implementation.
+type postgresPgauditResource struct {
+	providerData *providerData
+}
+
+// postgresPgauditResourceModel maps the resource schema data.
+type postgresPgauditResourceModel struct {
+	postgresConnectionModel
+	RoleName         types.String `tfsdk:"role_name"`
+	Log              types.List   `tfsdk:"log"`
+	Scope            types.String `tfsdk:"scope"`
+	TargetRole       types.String `tfsdk:"target_role"`
+	ExtensionVersion types.String `tfsdk:"extension_version"`
+	ID               types.String `tfsdk:"id"`
+}
+
+// Metadata returns the resource type name.
+func (r *postgresPgauditResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_postgres_pgaudit"
+}
+
+// Schema defines the schema for the resource.
+func (r *postgresPgauditResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Onboards a PostgreSQL database to pgaudit: installs the extension, creates the audit role " +
+			"and sets pgaudit.log and pgaudit.role at the database or role level. " +
+			"pgaudit must already be listed in shared_preload_libraries.",
+		Attributes: postgresConnectionResourceAttributes(),
+	}
+
+	resp.Schema.Attributes["role_name"] = schema.StringAttribute{
+		Description: "Name of the audit role used for object auditing (pgaudit.role). Defaults to rds_pgaudit.",
+		Optional:    true,
+		Computed:    true,
+		Default:     stringdefault.StaticString(defaultPgauditRoleName),
+		PlanModifiers: []planmodifier.String{
+			stringplanmodifier.RequiresReplace(),
+		},
+	}
+	resp.Schema.Attributes["log"] = schema.ListAttribute{
+		Description: "Statement classes to log through pgaudit.log, for example ddl, role, read, write or all.",
+		ElementType: types.StringType,
+		Required:    true,
+	}
+	resp.Schema.Attributes["scope"] = schema.StringAttribute{
+		Description: "Level at which the pgaudit settings are applied: database or role. Defaults to database.",
+		Optional:    true,
+		Computed:    true,
+		Default:     stringdefault.StaticString(pgauditScopeDatabase),
+		PlanModifiers: []planmodifier.String{
+			stringplanmodifier.RequiresReplace(),
+		},
+	}
+	resp.Schema.Attributes["target_role"] = schema.StringAttribute{
+		Description: "Role whose settings are changed when scope is role.",
+		Optional:    true,
+		PlanModifiers: []planmodifier.String{
+			stringplanmodifier.RequiresReplace(),
+		},
+	}
+	resp.Schema.Attributes["extension_version"] = schema.StringAttribute{
+		Description: "Installed version of the pgaudit extension.",
+		Computed:    true,
+	}
+	resp.Schema.Attributes["id"] = schema.StringAttribute{
+		Description: "Identifier of the resource.",
+		Computed:    true,
+		PlanModifiers: []planmodifier.String{
+			stringplanmodifier.UseStateForUnknown(),
+		},
+	}
+}
+
+// Configure keeps the provider data, which is used to generate IAM auth tokens.
+func (r *postgresPgauditResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	tflog.Info(ctx, "Configuring PostgreSQL pgaudit resource")
+
+	// If provider is not configured, return
+	if req.ProviderData == nil {
+		return
+	}
+
+	pd := providerDataFrom(req.ProviderData, &resp.Diagnostics)
+	if pd == nil {
+		return
+	}
+
+	r.providerData = pd
+}
+
+// Create installs pgaudit, creates the audit role and applies the settings.
+func (r *postgresPgauditResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan postgresPgauditResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	conn, err := plan.connect(ctx, r.providerData)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to Connect to PostgreSQL", fmt.Sprintf("Error connecting to PostgreSQL: %s", err))
+		return
+	}
+	defer conn.Close(ctx)
+
+	tflog.Info(ctx, "Installing pgaudit extension", map[string]interface{}{"db_name": conn.Config().Database})
+	if _, err := conn.Exec(ctx, "CREATE EXTENSION IF NOT EXISTS pgaudit"); err != nil {
+		resp.Diagnostics.AddError("Unable to create pgaudit extension", fmt.Sprintf("Error creating pgaudit extension: %s", err))
+		return
+	}
+
+	var roleExists bool
+	err = conn.QueryRow(ctx, "SELECT EXISTS(SELECT 1 FROM pg_roles WHERE rolname = $1)", plan.RoleName.ValueString()).Scan(&roleExists)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to Query PostgreSQL", fmt.Sprintf("Error querying PostgreSQL: %s", err))
+		return
+	}
+	if !roleExists {
+		tflog.Info(ctx, "Creating pgaudit role", map[string]interface{}{"role_name": plan.RoleName.ValueString()})
+		if _, err := conn.Exec(ctx, "CREATE ROLE "+quotePostgresIdentifier(plan.RoleName.ValueString())+" NOLOGIN"); err != nil {
+			resp.Diagnostics.AddError("Unable to create pgaudit role", fmt.Sprintf("Error creating role %s: %s", plan.RoleName.ValueString(), err))
+			return
+		}
+	}
+
+	if err := r.applySettings(ctx, conn, &plan); err != nil {
+		resp.Diagnostics.AddError("Unable to apply pgaudit settings", err.Error())
+		return
+	}
+
+	if err := r.readExtensionVersion(ctx, conn, &plan); err != nil {
+		resp.Diagnostics.AddError("Unable to read pgaudit extension", err.Error())
+		return
+	}
+	plan.ID = types.StringValue(pgauditID(&plan, conn.Config().Database))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Read refreshes the extension and settings so that out-of-band changes show up as drift.
+func (r *postgresPgauditResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state postgresPgauditResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	conn, err := state.connect(ctx, r.providerData)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to Connect to PostgreSQL", fmt.Sprintf("Error connecting to PostgreSQL: %s", err))
+		return
+	}
+	defer conn.Close(ctx)
+
+	err = r.readExtensionVersion(ctx, conn, &state)
+	if errors.Is(err, pgx.ErrNoRows) {
+		tflog.Warn(ctx, "pgaudit extension not found, removing from state", map[string]interface{}{"db_name": state.DBName.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to read pgaudit extension", err.Error())
+		return
+	}
+
+	var roleExists bool
+	err = conn.QueryRow(ctx, "SELECT EXISTS(SELECT 1 FROM pg_roles WHERE rolname = $1)", state.RoleName.ValueString()).Scan(&roleExists)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to Query PostgreSQL", fmt.Sprintf("Error querying PostgreSQL: %s", err))
+		return
+	}
+	if !roleExists {
+		tflog.Warn(ctx, "pgaudit role not found, removing from state", map[string]interface{}{"role_name": state.RoleName.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// Warn when the library is not preloaded, the settings have no effect without it
+	var preload string
+	err = conn.QueryRow(ctx, "SELECT setting FROM pg_settings WHERE name = 'shared_preload_libraries'").Scan(&preload)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to Query PostgreSQL", fmt.Sprintf("Error querying pg_settings: %s", err))
+		return
+	}
+	if !slices.ContainsFunc(splitPostgresList(preload), func(lib string) bool { return strings.EqualFold(lib, "pgaudit") }) {
+		resp.Diagnostics.AddWarning(
+			"pgaudit is not preloaded",
+			"shared_preload_libraries does not include pgaudit, so no audit records are written. Add it to the DB parameter group and reboot the instance.",
+		)
+	}
+
+	settings, err := r.readSettings(ctx, conn, &state)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to read pgaudit settings", err.Error())
+		return
+	}
+
+	state.Log = pgauditLogValue(ctx, state.Log, splitPostgresList(settings["pgaudit.log"]), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	// An empty value means pgaudit.role was reset outside Terraform
+	state.RoleName = types.StringValue(settings["pgaudit.role"])
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update re-applies the pgaudit settings.
+func (r *postgresPgauditResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan postgresPgauditResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	conn, err := plan.connect(ctx, r.providerData)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to Connect to PostgreSQL", fmt.Sprintf("Error connecting to PostgreSQL: %s", err))
+		return
+	}
+	defer conn.Close(ctx)
+
+	if err := r.applySettings(ctx, conn, &plan); err != nil {
+		resp.Diagnostics.AddError("Unable to apply pgaudit settings", err.Error())
+		return
+	}
+
+	if err := r.readExtensionVersion(ctx, conn, &plan); err != nil {
+		resp.Diagnostics.AddError("Unable to read pgaudit extension", err.Error())
+		return
+	}
+	plan.ID = types.StringValue(pgauditID(&plan, conn.Config().Database))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Delete resets the pgaudit settings. The extension and the audit role are kept
+// because other sessions or grants may depend on them.
+func (r *postgresPgauditResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state postgresPgauditResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	conn, err := state.connect(ctx, r.providerData)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to Connect to PostgreSQL", fmt.Sprintf("Error connecting to PostgreSQL: %s", err))
+		return
+	}
+	defer conn.Close(ctx)
+
+	target, err := pgauditSettingsTarget(&state, conn.Config().Database)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid pgaudit scope", err.Error())
+		return
+	}
+
+	for _, name := range []string{"pgaudit.log", "pgaudit.role"} {
+		if _, err := conn.Exec(ctx, fmt.Sprintf("ALTER %s RESET %s", target, name)); err != nil {
+			resp.Diagnostics.AddError("Unable to reset pgaudit settings", fmt.Sprintf("Error resetting %s: %s", name, err))
+			return
+		}
+	}
+}
+
+// applySettings sets pgaudit.log and pgaudit.role on the configured database or role
+func (r *postgresPgauditResource) applySettings(ctx context.Context, conn *pgx.Conn, data *postgresPgauditResourceModel) error {
+	target, err := pgauditSettingsTarget(data, conn.Config().Database)
+	if err != nil {
+		return err
+	}
+
+	var logClasses []string
+	if diags := data.Log.ElementsAs(ctx, &logClasses, false); diags.HasError() {
+		return fmt.Errorf("invalid log classes: %v", diags)
+	}
+
+	settings := [][2]string{
+		{"pgaudit.log", strings.Join(logClasses, ",")},
+		{"pgaudit.role", data.RoleName.ValueString()},
+	}
+	for _, setting := range settings {
+		tflog.Debug(ctx, "Setting pgaudit parameter", map[string]interface{}{
+			"target": target,
+			"name":   setting[0],
+			"value":  setting[1],
+		})
+		stmt := fmt.Sprintf("ALTER %s SET %s = %s", target, setting[0], quotePostgresLiteral(setting[1]))
+		if _, err := conn.Exec(ctx, stmt); err != nil {
+			return fmt.Errorf("error setting %s: %w", setting[0], err)
+		}
+	}
+
+	return nil
+}
+
+// readSettings returns the pgaudit settings stored for the configured database or role. They are read
+// from pg_db_role_setting rather than pg_settings, where session, role or cluster values would mask them.
+func (r *postgresPgauditResource) readSettings(ctx context.Context, conn *pgx.Conn, data *postgresPgauditResourceModel) (map[string]string, error) {
+	var config []string
+
+	if data.Scope.ValueString() == pgauditScopeRole {
+		err := conn.QueryRow(ctx, `SELECT COALESCE(s.setconfig, '{}')
+			FROM pg_roles r
+			LEFT JOIN pg_db_role_setting s ON s.setrole = r.oid AND s.setdatabase = 0
+			WHERE r.rolname = $1`, data.TargetRole.ValueString()).Scan(&config)
+		if err != nil {
+			return nil, fmt.Errorf("error querying settings of role %s: %w", data.TargetRole.ValueString(), err)
+		}
+		return parsePostgresSetconfig(config), nil
+	}
+
+	err := conn.QueryRow(ctx, `SELECT COALESCE(s.setconfig, '{}')
+		FROM pg_database d
+		LEFT JOIN pg_db_role_setting s ON s.setdatabase = d.oid AND s.setrole = 0
+		WHERE d.datname = current_database()`).Scan(&config)
+	if err != nil {
+		return nil, fmt.Errorf("error querying settings of database %s: %w", conn.Config().Database, err)
+	}
+	return parsePostgresSetconfig(config), nil
+}
+
+// parsePostgresSetconfig converts the name=value entries of pg_db_role_setting.setconfig into a map
+func parsePostgresSetconfig(config []string) map[string]string {
+	settings := map[string]string{}
+	for _, entry := range config {
+		if name, value, ok := strings.Cut(entry, "="); ok {
+			settings[name] = value
+		}
+	}
+	return settings
+}
+
+// readExtensionVersion stores the installed pgaudit version, returning pgx.ErrNoRows when it is not installed
+func (r *postgresPgauditResource) readExtensionVersion(ctx context.Context, conn *pgx.Conn, data *postgresPgauditResourceModel) error {
+	var version string
+	err := conn.QueryRow(ctx, "SELECT extversion FROM pg_extension WHERE extname = 'pgaudit'").Scan(&version)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return err
+		}
+		return fmt.Errorf("error querying pg_extension: %w", err)
+	}
+
+	data.ExtensionVersion = types.StringValue(version)
+	return nil
+}
+
+// pgauditSettingsTarget returns the DATABASE or ROLE clause the settings are applied to. The database
+// name is taken from the connection since it may come from a credentials secret.
+func pgauditSettingsTarget(data *postgresPgauditResourceModel, dbName string) (string, error) {
+	switch data.Scope.ValueString() {
+	case pgauditScopeDatabase:
+		return "DATABASE " + quotePostgresIdentifier(dbName), nil
+	case pgauditScopeRole:
+		if data.TargetRole.ValueString() == "" {
+			return "", errors.New("target_role is required when scope is role")
+		}
+		return "ROLE " + quotePostgresIdentifier(data.TargetRole.ValueString()), nil
+	default:
+		return "", fmt.Errorf("scope must be %q or %q, got %q", pgauditScopeDatabase, pgauditScopeRole, data.Scope.ValueString())
+	}
+}
+
+func pgauditID(data *postgresPgauditResourceModel, dbName string) string {
+	if data.Scope.ValueString() == pgauditScopeRole {
+		return fmt.Sprintf("%s/role/%s", dbName, data.TargetRole.ValueString())
+	}
+	return fmt.Sprintf("%s/database", dbName)
+}
+
+// pgauditLogValue returns the stored log classes as a list value. pgaudit matches the classes case
+// insensitively, so the configured value is kept when it names the same classes.
+func pgauditLogValue(ctx context.Context, current types.List, stored []string, diags *diag.Diagnostics) types.List {
+	var configured []string
+	if !current.IsNull() && !current.IsUnknown() {
+		if d := current.ElementsAs(ctx, &configured, false); d.HasError() {
+			diags.Append(d...)
+			return current
+		}
+	}
+
+	if slices.EqualFunc(configured, stored, strings.EqualFold) {
+		return current
+	}
+	return stringListValue(ctx, stored, diags)
+}
+
+// splitPostgresList splits a comma separated setting such as pgaudit.log or shared_preload_libraries
+func splitPostgresList(value string) []string {
+	items := []string{}
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,83 @@
+// Copyright (c) IBM Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestSplitPostgresList(t *testing.T) {
+	got := splitPostgresList(" DDL, role,,write ")
+	if want := []string{"DDL", "role", "write"}; !slices.Equal(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+	if got := splitPostgresList(""); len(got) != 0 {
+		t.Errorf("expected no items, got %v", got)
+	}
+}
+
+func TestParsePostgresSetconfig(t *testing.T) {
+	got := parsePostgresSetconfig([]string{"pgaudit.log=ddl,role", "pgaudit.role=rds_pgaudit", "search_path=a=b"})
+	want := map[string]string{"pgaudit.log": "ddl,role", "pgaudit.role": "rds_pgaudit", "search_path": "a=b"}
+	if !maps.Equal(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+	if got := parsePostgresSetconfig(nil); len(got) != 0 || got["pgaudit.role"] != "" {
+		t.Errorf("expected no settings, got %v", got)
+	}
+}
+
+func TestPgauditLogValue(t *testing.T) {
+	ctx := context.Background()
+	var diags diag.Diagnostics
+
+	configured, _ := types.ListValueFrom(ctx, types.StringType, []string{"DDL", "Role"})
+	if got := pgauditLogValue(ctx, configured, []string{"ddl", "role"}, &diags); !got.Equal(configured) {
+		t.Errorf("expected the configured value to be kept, got %s", got)
+	}
+
+	got := pgauditLogValue(ctx, configured, []string{"ddl"}, &diags)
+	want, _ := types.ListValueFrom(ctx, types.StringType, []string{"ddl"})
+	if !got.Equal(want) {
+		t.Errorf("expected the stored classes, got %s", got)
+	}
+
+	if diags.HasError() {
+		t.Errorf("unexpected diagnostics: %v", diags)
+	}
+}
+
+func TestPgauditSettingsTarget(t *testing.T) {
+	data := &postgresPgauditResourceModel{Scope: types.StringValue(pgauditScopeDatabase)}
+	if got, err := pgauditSettingsTarget(data, "orders"); err != nil || got != `DATABASE "orders"` {
+		t.Errorf("unexpected database target %q, %v", got, err)
+	}
+
+	data.Scope = types.StringValue(pgauditScopeRole)
+	if _, err := pgauditSettingsTarget(data, "orders"); err == nil {
+		t.Error("expected an error when scope is role without target_role")
+	}
+
+	data.TargetRole = types.StringValue(`app"user`)
+	if got, err := pgauditSettingsTarget(data, "orders"); err != nil || got != `ROLE "app""user"` {
+		t.Errorf("unexpected role target %q, %v", got, err)
+	}
+
+	data.Scope = types.StringValue("cluster")
+	if _, err := pgauditSettingsTarget(data, "orders"); err == nil {
+		t.Error("expected an error for an unknown scope")
+	}
+}
+
+func TestQuotePostgresLiteral(t *testing.T) {
+	if got := quotePostgresLiteral("it's"); got != "'it''s'" {
+		t.Errorf("unexpected literal %s", got)
+	}
+}
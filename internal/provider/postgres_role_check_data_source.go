@@ -5,17 +5,22 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
 var _ datasource.DataSource = &postgresRoleCheckDataSource{}
+var _ datasource.DataSourceWithConfigure = &postgresRoleCheckDataSource{}
 
 // NewPostgresRoleCheckDataSource is a helper function to simplify the provider implementation.
 func NewPostgresRoleCheckDataSource() datasource.DataSource {
@@ -23,20 +28,33 @@ func NewPostgresRoleCheckDataSource() datasource.DataSource {
 }
 
 // postgresRoleCheckDataSource is the data source implementation.
-type postgresRoleCheckDataSource struct{}
+type postgresRoleCheckDataSource struct {
+	providerData *providerData
+}
 
 // postgresRoleCheckDataSourceModel maps the data source schema data.
 type postgresRoleCheckDataSourceModel struct {
-	Host     types.String `tfsdk:"host"`
-	Port     types.String `tfsdk:"port"`
-	Username types.String `tfsdk:"username"`
-	Password types.String `tfsdk:"password"`
-	DBName   types.String `tfsdk:"db_name"`
-	SSLMode  types.String `tfsdk:"ssl_mode"`
-	RoleName types.String `tfsdk:"role_name"`
-	Exists   types.Bool   `tfsdk:"exists"`
+	postgresConnectionModel
+	RoleName           types.String `tfsdk:"role_name"`
+	PrivilegesDatabase types.String `tfsdk:"privileges_database"`
+	PrivilegesSchema   types.String `tfsdk:"privileges_schema"`
+	Exists             types.Bool   `tfsdk:"exists"`
+	Superuser          types.Bool   `tfsdk:"superuser"`
+	CanLogin           types.Bool   `tfsdk:"can_login"`
+	Replication        types.Bool   `tfsdk:"replication"`
+	ConnectionLimit    types.Int64  `tfsdk:"connection_limit"`
+	ValidUntil         types.String `tfsdk:"valid_until"`
+	MemberOf           types.List   `tfsdk:"member_of"`
+	DatabasePrivileges types.List   `tfsdk:"database_privileges"`
+	SchemaPrivileges   types.List   `tfsdk:"schema_privileges"`
 }
 
+// Privileges checked by has_database_privilege and has_schema_privilege
+var (
+	postgresDatabasePrivileges = []string{"CREATE", "CONNECT", "TEMPORARY"}
+	postgresSchemaPrivileges   = []string{"CREATE", "USAGE"}
+)
+
 // Metadata returns the data source type name.
 func (d *postgresRoleCheckDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_postgres_role_check"
@@ -45,47 +63,80 @@ func (d *postgresRoleCheckDataSource) Metadata(_ context.Context, req datasource
 // Schema defines the schema for the data source.
 func (d *postgresRoleCheckDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		Description: "Checks if a role exists in PostgreSQL.",
-		Attributes: map[string]schema.Attribute{
-			"host": schema.StringAttribute{
-				Description: "PostgreSQL server hostname or IP address.",
-				Required:    true,
-			},
-			"port": schema.StringAttribute{
-				Description: "PostgreSQL server port.",
-				Optional:    true,
-				Computed:    true,
-			},
-			"username": schema.StringAttribute{
-				Description: "PostgreSQL username.",
-				Required:    true,
-			},
-			"password": schema.StringAttribute{
-				Description: "PostgreSQL password.",
-				Required:    true,
-				Sensitive:   true,
-			},
-			"db_name": schema.StringAttribute{
-				Description: "PostgreSQL database name.",
-				Required:    true,
-			},
-			"ssl_mode": schema.StringAttribute{
-				Description: "PostgreSQL SSL mode (disable, require, verify-ca, verify-full).",
-				Optional:    true,
-				Computed:    true,
-			},
-			"role_name": schema.StringAttribute{
-				Description: "Name of the role to check for.",
-				Required:    true,
-			},
-			"exists": schema.BoolAttribute{
-				Description: "Indicates whether the role exists.",
-				Computed:    true,
-			},
-		},
+		Description: "Checks if a role exists in PostgreSQL and reports its attributes, memberships and privileges.",
+		Attributes:  postgresConnectionDataSourceAttributes(),
+	}
+
+	resp.Schema.Attributes["role_name"] = schema.StringAttribute{
+		Description: "Name of the role to check for.",
+		Required:    true,
+	}
+	resp.Schema.Attributes["privileges_database"] = schema.StringAttribute{
+		Description: "Database whose privileges held by the role are reported in database_privileges.",
+		Optional:    true,
+	}
+	resp.Schema.Attributes["privileges_schema"] = schema.StringAttribute{
+		Description: "Schema of the connected database whose privileges held by the role are reported in schema_privileges.",
+		Optional:    true,
+	}
+	resp.Schema.Attributes["exists"] = schema.BoolAttribute{
+		Description: "Indicates whether the role exists.",
+		Computed:    true,
+	}
+	resp.Schema.Attributes["superuser"] = schema.BoolAttribute{
+		Description: "Whether the role is a superuser (rolsuper).",
+		Computed:    true,
+	}
+	resp.Schema.Attributes["can_login"] = schema.BoolAttribute{
+		Description: "Whether the role can log in (rolcanlogin).",
+		Computed:    true,
+	}
+	resp.Schema.Attributes["replication"] = schema.BoolAttribute{
+		Description: "Whether the role can initiate replication (rolreplication).",
+		Computed:    true,
+	}
+	resp.Schema.Attributes["connection_limit"] = schema.Int64Attribute{
+		Description: "Connection limit of the role (rolconnlimit), -1 means no limit.",
+		Computed:    true,
+	}
+	resp.Schema.Attributes["valid_until"] = schema.StringAttribute{
+		Description: "Password expiry of the role (rolvaliduntil) as an RFC3339 timestamp or infinity. Null when not set.",
+		Computed:    true,
+	}
+	resp.Schema.Attributes["member_of"] = schema.ListAttribute{
+		Description: "Roles the role is a member of, directly or through other roles.",
+		ElementType: types.StringType,
+		Computed:    true,
+	}
+	resp.Schema.Attributes["database_privileges"] = schema.ListAttribute{
+		Description: "Privileges the role holds on privileges_database (CREATE, CONNECT, TEMPORARY).",
+		ElementType: types.StringType,
+		Computed:    true,
+	}
+	resp.Schema.Attributes["schema_privileges"] = schema.ListAttribute{
+		Description: "Privileges the role holds on privileges_schema (CREATE, USAGE).",
+		ElementType: types.StringType,
+		Computed:    true,
 	}
 }
 
+// Configure keeps the provider data, which is used to generate IAM auth tokens.
+func (d *postgresRoleCheckDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	tflog.Info(ctx, "Configuring PostgreSQL role check data source")
+
+	// If provider is not configured, return
+	if req.ProviderData == nil {
+		return
+	}
+
+	pd := providerDataFrom(req.ProviderData, &resp.Diagnostics)
+	if pd == nil {
+		return
+	}
+
+	d.providerData = pd
+}
+
 // Read refreshes the Terraform state with the latest data.
 func (d *postgresRoleCheckDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
 	// Get current state
@@ -96,27 +147,8 @@ func (d *postgresRoleCheckDataSource) Read(ctx context.Context, req datasource.R
 		return
 	}
 
-	// Set default values if not provided
-	if state.Port.IsNull() || state.Port.ValueString() == "" {
-		state.Port = types.StringValue("5432")
-	}
-
-	if state.SSLMode.IsNull() || state.SSLMode.ValueString() == "" {
-		state.SSLMode = types.StringValue("disable")
-	}
-
-	// Build connection string
-	connString := fmt.Sprintf("postgres://%s:%s@%s:%s/%s?sslmode=%s",
-		state.Username.ValueString(),
-		state.Password.ValueString(),
-		state.Host.ValueString(),
-		state.Port.ValueString(),
-		state.DBName.ValueString(),
-		state.SSLMode.ValueString(),
-	)
-
 	// Connect to PostgreSQL
-	conn, err := pgx.Connect(ctx, connString)
+	conn, err := state.connect(ctx, d.providerData)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to Connect to PostgreSQL",
@@ -126,12 +158,19 @@ func (d *postgresRoleCheckDataSource) Read(ctx context.Context, req datasource.R
 	}
 	defer conn.Close(ctx)
 
-	// Query to check if the role exists
-	query := "SELECT EXISTS(SELECT 1 FROM pg_roles WHERE rolname = $1)"
+	roleName := state.RoleName.ValueString()
 
-	var exists bool
-	err = conn.QueryRow(ctx, query, state.RoleName.ValueString()).Scan(&exists)
-	if err != nil {
+	// Query the role attributes, no rows means the role does not exist
+	query := "SELECT rolsuper, rolcanlogin, rolreplication, rolconnlimit, rolvaliduntil FROM pg_roles WHERE rolname = $1"
+
+	var (
+		superuser, canLogin, replication bool
+		connLimit                        int64
+		validUntil                       pgtype.Timestamptz
+	)
+	err = conn.QueryRow(ctx, query, roleName).Scan(&superuser, &canLogin, &replication, &connLimit, &validUntil)
+	exists := !errors.Is(err, pgx.ErrNoRows)
+	if err != nil && exists {
 		resp.Diagnostics.AddError(
 			"Unable to Query PostgreSQL",
 			fmt.Sprintf("Error querying PostgreSQL: %s", err),
@@ -141,9 +180,54 @@ func (d *postgresRoleCheckDataSource) Read(ctx context.Context, req datasource.R
 
 	// Set state
 	state.Exists = types.BoolValue(exists)
+	state.Superuser = types.BoolNull()
+	state.CanLogin = types.BoolNull()
+	state.Replication = types.BoolNull()
+	state.ConnectionLimit = types.Int64Null()
+	state.ValidUntil = types.StringNull()
+	state.MemberOf = types.ListNull(types.StringType)
+	state.DatabasePrivileges = types.ListNull(types.StringType)
+	state.SchemaPrivileges = types.ListNull(types.StringType)
+
+	if exists {
+		state.Superuser = types.BoolValue(superuser)
+		state.CanLogin = types.BoolValue(canLogin)
+		state.Replication = types.BoolValue(replication)
+		state.ConnectionLimit = types.Int64Value(connLimit)
+		state.ValidUntil = formatPostgresTimestamp(validUntil)
+
+		memberOf, err := postgresRoleMembershipsRecursive(ctx, conn, roleName)
+		if err != nil {
+			resp.Diagnostics.AddError("Unable to Query PostgreSQL", err.Error())
+			return
+		}
+		state.MemberOf = stringListValue(ctx, memberOf, &resp.Diagnostics)
+
+		if !state.PrivilegesDatabase.IsNull() {
+			privileges, err := postgresPrivileges(ctx, conn, "has_database_privilege", roleName, state.PrivilegesDatabase.ValueString(), postgresDatabasePrivileges)
+			if err != nil {
+				resp.Diagnostics.AddError("Unable to Query PostgreSQL", err.Error())
+				return
+			}
+			state.DatabasePrivileges = stringListValue(ctx, privileges, &resp.Diagnostics)
+		}
+
+		if !state.PrivilegesSchema.IsNull() {
+			privileges, err := postgresPrivileges(ctx, conn, "has_schema_privilege", roleName, state.PrivilegesSchema.ValueString(), postgresSchemaPrivileges)
+			if err != nil {
+				resp.Diagnostics.AddError("Unable to Query PostgreSQL", err.Error())
+				return
+			}
+			state.SchemaPrivileges = stringListValue(ctx, privileges, &resp.Diagnostics)
+		}
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
 
 	// Log the result
-	tflog.Info(ctx, fmt.Sprintf("Role %s exists: %t", state.RoleName.ValueString(), exists))
+	tflog.Info(ctx, fmt.Sprintf("Role %s exists: %t", roleName, exists))
 
 	// Save updated state
 	diags = resp.State.Set(ctx, &state)
@@ -152,3 +236,65 @@ func (d *postgresRoleCheckDataSource) Read(ctx context.Context, req datasource.R
 		return
 	}
 }
+
+// postgresRoleMembershipsRecursive returns every role the given role is a member of, following nested grants
+func postgresRoleMembershipsRecursive(ctx context.Context, q postgresQuerier, roleName string) ([]string, error) {
+	rows, err := q.Query(ctx, `WITH RECURSIVE memberships(oid) AS (
+			SELECT m.roleid
+			FROM pg_auth_members m
+			JOIN pg_roles u ON u.oid = m.member
+			WHERE u.rolname = $1
+		UNION
+			SELECT m.roleid
+			FROM pg_auth_members m
+			JOIN memberships ms ON m.member = ms.oid
+		)
+		SELECT r.rolname FROM memberships ms JOIN pg_roles r ON r.oid = ms.oid ORDER BY r.rolname`, roleName)
+	if err != nil {
+		return nil, fmt.Errorf("error querying memberships of role %s: %w", roleName, err)
+	}
+
+	memberOf, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return nil, fmt.Errorf("error reading memberships of role %s: %w", roleName, err)
+	}
+	return memberOf, nil
+}
+
+// postgresPrivileges returns the privileges the role holds on the object, checked with the given has_*_privilege function
+func postgresPrivileges(ctx context.Context, q postgresQuerier, function, roleName, object string, privileges []string) ([]string, error) {
+	query := fmt.Sprintf("SELECT p FROM unnest($1::text[]) AS p WHERE %s($2, $3, p)", function)
+	rows, err := q.Query(ctx, query, privileges, roleName, object)
+	if err != nil {
+		return nil, fmt.Errorf("error checking privileges of role %s on %s: %w", roleName, object, err)
+	}
+
+	held, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return nil, fmt.Errorf("error reading privileges of role %s on %s: %w", roleName, object, err)
+	}
+	return held, nil
+}
+
+// formatPostgresTimestamp renders a nullable timestamptz as RFC3339 or infinity
+func formatPostgresTimestamp(value pgtype.Timestamptz) types.String {
+	switch {
+	case !value.Valid:
+		return types.StringNull()
+	case value.InfinityModifier == pgtype.Infinity:
+		return types.StringValue("infinity")
+	case value.InfinityModifier == pgtype.NegativeInfinity:
+		return types.StringValue("-infinity")
+	}
+	return types.StringValue(value.Time.UTC().Format(time.RFC3339))
+}
+
+// stringListValue converts a string slice to a list value, an empty slice becomes an empty list
+func stringListValue(ctx context.Context, values []string, diags *diag.Diagnostics) types.List {
+	if values == nil {
+		values = []string{}
+	}
+	listValue, d := types.ListValueFrom(ctx, types.StringType, values)
+	diags.Append(d...)
+	return listValue
+}
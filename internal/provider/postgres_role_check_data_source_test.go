@@ -0,0 +1,45 @@
+// Copyright (c) IBM Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func TestFormatPostgresTimestamp(t *testing.T) {
+	cases := map[string]struct {
+		value pgtype.Timestamptz
+		want  string
+		null  bool
+	}{
+		"null":     {value: pgtype.Timestamptz{}, null: true},
+		"infinity": {value: pgtype.Timestamptz{Valid: true, InfinityModifier: pgtype.Infinity}, want: "infinity"},
+		"finite": {
+			value: pgtype.Timestamptz{Valid: true, Time: time.Date(2030, 1, 1, 2, 0, 0, 0, time.FixedZone("CET", 3600))},
+			want:  "2030-01-01T01:00:00Z",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := formatPostgresTimestamp(tc.value)
+			if got.IsNull() != tc.null || (!tc.null && got.ValueString() != tc.want) {
+				t.Errorf("expected %q (null %t), got %s", tc.want, tc.null, got)
+			}
+		})
+	}
+}
+
+func TestStringListValue(t *testing.T) {
+	var diags diag.Diagnostics
+	got := stringListValue(context.Background(), nil, &diags)
+	if diags.HasError() || got.IsNull() || len(got.Elements()) != 0 {
+		t.Errorf("expected an empty list, got %s (%v)", got, diags)
+	}
+}
@@ -0,0 +1,464 @@
+// Copyright (c) IBM Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var _ resource.Resource = &postgresRoleResource{}
+var _ resource.ResourceWithConfigure = &postgresRoleResource{}
+var _ resource.ResourceWithImportState = &postgresRoleResource{}
+
+// NewPostgresRoleResource is a helper function to simplify the provider implementation.
+func NewPostgresRoleResource() resource.Resource {
+	return &postgresRoleResource{}
+}
+
+// postgresRoleResource is the resource implementation.
+type postgresRoleResource struct {
+	providerData *providerData
+}
+
+// postgresRoleResourceModel maps the resource schema data.
+type postgresRoleResourceModel struct {
+	postgresConnectionModel
+	RoleName        types.String `tfsdk:"role_name"`
+	Login           types.Bool   `tfsdk:"login"`
+	RolePassword    types.String `tfsdk:"role_password"`
+	ConnectionLimit types.Int64  `tfsdk:"connection_limit"`
+	ValidUntil      types.String `tfsdk:"valid_until"`
+	MemberOf        types.Set    `tfsdk:"member_of"`
+	ID              types.String `tfsdk:"id"`
+}
+
+// Metadata returns the resource type name.
+func (r *postgresRoleResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_postgres_role"
+}
+
+// Schema defines the schema for the resource.
+func (r *postgresRoleResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Creates and manages a PostgreSQL role, for example the Guardium collector user. " +
+			"Import with role_name,credentials_secret_arn or role_name,credentials_secret_arn,ssl_mode; the secret provides the connection used to read the role.",
+		Attributes: postgresConnectionResourceAttributes(),
+	}
+
+	resp.Schema.Attributes["role_name"] = schema.StringAttribute{
+		Description: "Name of the role to manage.",
+		Required:    true,
+		PlanModifiers: []planmodifier.String{
+			stringplanmodifier.RequiresReplace(),
+		},
+	}
+	resp.Schema.Attributes["login"] = schema.BoolAttribute{
+		Description: "Whether the role can log in. Defaults to false.",
+		Optional:    true,
+		Computed:    true,
+		Default:     booldefault.StaticBool(false),
+	}
+	resp.Schema.Attributes["role_password"] = schema.StringAttribute{
+		Description: "Password of the role. The password cannot be read back, so it is not checked for drift.",
+		Optional:    true,
+		Sensitive:   true,
+	}
+	resp.Schema.Attributes["connection_limit"] = schema.Int64Attribute{
+		Description: "Maximum number of concurrent connections for the role. Defaults to -1 (no limit).",
+		Optional:    true,
+		Computed:    true,
+		Default:     int64default.StaticInt64(-1),
+	}
+	resp.Schema.Attributes["valid_until"] = schema.StringAttribute{
+		Description: "RFC3339 timestamp after which the role password is no longer valid, for example 2030-01-01T00:00:00Z, or infinity.",
+		Optional:    true,
+	}
+	resp.Schema.Attributes["member_of"] = schema.SetAttribute{
+		Description: "Roles this role is granted membership in, for example rds_superuser or pg_read_all_settings. " +
+			"When set, memberships not listed here are revoked.",
+		ElementType: types.StringType,
+		Optional:    true,
+	}
+	resp.Schema.Attributes["id"] = schema.StringAttribute{
+		Description: "Identifier of the resource. Set to the role name.",
+		Computed:    true,
+		PlanModifiers: []planmodifier.String{
+			stringplanmodifier.UseStateForUnknown(),
+		},
+	}
+}
+
+// Configure keeps the provider data, which is used to generate IAM auth tokens.
+func (r *postgresRoleResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	tflog.Info(ctx, "Configuring PostgreSQL role resource")
+
+	// If provider is not configured, return
+	if req.ProviderData == nil {
+		return
+	}
+
+	pd := providerDataFrom(req.ProviderData, &resp.Diagnostics)
+	if pd == nil {
+		return
+	}
+
+	r.providerData = pd
+}
+
+// Create creates the role and grants its memberships.
+func (r *postgresRoleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan postgresRoleResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := checkValidUntil(plan.ValidUntil); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("valid_until"), "Invalid valid_until", err.Error())
+		return
+	}
+
+	var memberOf []string
+	resp.Diagnostics.Append(plan.MemberOf.ElementsAs(ctx, &memberOf, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	conn, err := plan.connect(ctx, r.providerData)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to Connect to PostgreSQL", fmt.Sprintf("Error connecting to PostgreSQL: %s", err))
+		return
+	}
+	defer conn.Close(ctx)
+
+	tx, err := conn.Begin(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to start transaction", fmt.Sprintf("Error starting transaction: %s", err))
+		return
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	roleName := plan.RoleName.ValueString()
+	tflog.Info(ctx, "Creating PostgreSQL role", map[string]interface{}{"role_name": roleName})
+
+	stmt := "CREATE ROLE " + quotePostgresIdentifier(roleName) + " WITH " + postgresRoleOptions(&plan)
+	if _, err := tx.Exec(ctx, stmt); err != nil {
+		resp.Diagnostics.AddError("Unable to create role", fmt.Sprintf("Error creating role %s: %s", roleName, err))
+		return
+	}
+
+	if err := grantPostgresRoles(ctx, tx, roleName, memberOf); err != nil {
+		resp.Diagnostics.AddError("Unable to grant role membership", err.Error())
+		return
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		resp.Diagnostics.AddError("Unable to commit transaction", fmt.Sprintf("Error creating role %s: %s", roleName, err))
+		return
+	}
+
+	plan.ID = types.StringValue(roleName)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Read refreshes the role attributes and memberships.
+func (r *postgresRoleResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state postgresRoleResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if state.Host.IsNull() && state.CredentialsSecretARN.IsNull() {
+		resp.Diagnostics.AddError(
+			"Missing PostgreSQL connection",
+			fmt.Sprintf("Role %s has neither host nor credentials_secret_arn in state. Import it with role_name,credentials_secret_arn.", state.RoleName.ValueString()),
+		)
+		return
+	}
+
+	conn, err := state.connect(ctx, r.providerData)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to Connect to PostgreSQL", fmt.Sprintf("Error connecting to PostgreSQL: %s", err))
+		return
+	}
+	defer conn.Close(ctx)
+
+	var (
+		canLogin   bool
+		connLimit  int64
+		validUntil pgtype.Timestamptz
+	)
+	err = conn.QueryRow(ctx, "SELECT rolcanlogin, rolconnlimit, rolvaliduntil FROM pg_roles WHERE rolname = $1",
+		state.RoleName.ValueString()).Scan(&canLogin, &connLimit, &validUntil)
+	if errors.Is(err, pgx.ErrNoRows) {
+		tflog.Warn(ctx, "PostgreSQL role not found, removing from state", map[string]interface{}{"role_name": state.RoleName.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to Query PostgreSQL", fmt.Sprintf("Error querying pg_roles: %s", err))
+		return
+	}
+
+	state.Login = types.BoolValue(canLogin)
+	state.ConnectionLimit = types.Int64Value(connLimit)
+	state.ValidUntil = refreshValidUntil(state.ValidUntil, validUntil)
+
+	// Only manage memberships when they are configured
+	if !state.MemberOf.IsNull() {
+		memberOf, err := postgresRoleMemberships(ctx, conn, state.RoleName.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Unable to Query PostgreSQL", err.Error())
+			return
+		}
+		setValue, diags := types.SetValueFrom(ctx, types.StringType, memberOf)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		state.MemberOf = setValue
+	}
+
+	state.ID = state.RoleName
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update alters the role and reconciles its memberships.
+func (r *postgresRoleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state postgresRoleResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := checkValidUntil(plan.ValidUntil); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("valid_until"), "Invalid valid_until", err.Error())
+		return
+	}
+
+	var wanted []string
+	resp.Diagnostics.Append(plan.MemberOf.ElementsAs(ctx, &wanted, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	conn, err := plan.connect(ctx, r.providerData)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to Connect to PostgreSQL", fmt.Sprintf("Error connecting to PostgreSQL: %s", err))
+		return
+	}
+	defer conn.Close(ctx)
+
+	tx, err := conn.Begin(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to start transaction", fmt.Sprintf("Error starting transaction: %s", err))
+		return
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	roleName := plan.RoleName.ValueString()
+	tflog.Info(ctx, "Altering PostgreSQL role", map[string]interface{}{"role_name": roleName})
+
+	options := postgresRoleOptions(&plan)
+	if plan.RolePassword.IsNull() && !state.RolePassword.IsNull() {
+		options += " PASSWORD NULL"
+	}
+	if plan.ValidUntil.IsNull() && !state.ValidUntil.IsNull() {
+		options += " VALID UNTIL 'infinity'"
+	}
+	if _, err := tx.Exec(ctx, "ALTER ROLE "+quotePostgresIdentifier(roleName)+" WITH "+options); err != nil {
+		resp.Diagnostics.AddError("Unable to alter role", fmt.Sprintf("Error altering role %s: %s", roleName, err))
+		return
+	}
+
+	if !plan.MemberOf.IsNull() {
+		current, err := postgresRoleMemberships(ctx, tx, roleName)
+		if err != nil {
+			resp.Diagnostics.AddError("Unable to Query PostgreSQL", err.Error())
+			return
+		}
+
+		var grants, revokes []string
+		for _, role := range wanted {
+			if !slices.Contains(current, role) {
+				grants = append(grants, role)
+			}
+		}
+		for _, role := range current {
+			if !slices.Contains(wanted, role) {
+				revokes = append(revokes, role)
+			}
+		}
+
+		if err := grantPostgresRoles(ctx, tx, roleName, grants); err != nil {
+			resp.Diagnostics.AddError("Unable to grant role membership", err.Error())
+			return
+		}
+		for _, role := range revokes {
+			tflog.Debug(ctx, "Revoking role membership", map[string]interface{}{"role_name": roleName, "member_of": role})
+			stmt := fmt.Sprintf("REVOKE %s FROM %s", quotePostgresIdentifier(role), quotePostgresIdentifier(roleName))
+			if _, err := tx.Exec(ctx, stmt); err != nil {
+				resp.Diagnostics.AddError("Unable to revoke role membership", fmt.Sprintf("Error revoking %s from %s: %s", role, roleName, err))
+				return
+			}
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		resp.Diagnostics.AddError("Unable to commit transaction", fmt.Sprintf("Error altering role %s: %s", roleName, err))
+		return
+	}
+
+	plan.ID = types.StringValue(roleName)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Delete drops the role.
+func (r *postgresRoleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state postgresRoleResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	conn, err := state.connect(ctx, r.providerData)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to Connect to PostgreSQL", fmt.Sprintf("Error connecting to PostgreSQL: %s", err))
+		return
+	}
+	defer conn.Close(ctx)
+
+	tflog.Info(ctx, "Dropping PostgreSQL role", map[string]interface{}{"role_name": state.RoleName.ValueString()})
+	if _, err := conn.Exec(ctx, "DROP ROLE IF EXISTS "+quotePostgresIdentifier(state.RoleName.ValueString())); err != nil {
+		resp.Diagnostics.AddError("Unable to drop role", fmt.Sprintf("Error dropping role %s: %s", state.RoleName.ValueString(), err))
+		return
+	}
+}
+
+// ImportState imports the resource into Terraform state. The ID carries the credentials secret,
+// since Read needs a connection and the configuration is not available during import.
+func (r *postgresRoleResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	roleName, secretARN, sslMode, err := parsePostgresRoleImportID(req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid import ID", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("role_name"), roleName)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("credentials_secret_arn"), secretARN)...)
+	if sslMode != "" {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("ssl_mode"), sslMode)...)
+	}
+}
+
+// parsePostgresRoleImportID splits an import ID of the form role_name,credentials_secret_arn[,ssl_mode]
+func parsePostgresRoleImportID(id string) (roleName, secretARN, sslMode string, err error) {
+	parts := strings.Split(id, ",")
+	if len(parts) < 2 || len(parts) > 3 || parts[0] == "" || parts[1] == "" {
+		return "", "", "", fmt.Errorf("import ID must be role_name,credentials_secret_arn or role_name,credentials_secret_arn,ssl_mode, got %q", id)
+	}
+	if len(parts) == 3 {
+		sslMode = parts[2]
+	}
+	return parts[0], parts[1], sslMode, nil
+}
+
+// checkValidUntil validates that valid_until is an RFC3339 timestamp or infinity, so that it can be
+// compared with the value read back from pg_roles
+func checkValidUntil(value types.String) error {
+	if value.IsNull() || value.IsUnknown() || value.ValueString() == "infinity" {
+		return nil
+	}
+	if _, err := time.Parse(time.RFC3339, value.ValueString()); err != nil {
+		return fmt.Errorf("valid_until must be an RFC3339 timestamp such as 2030-01-01T00:00:00Z or infinity, got %q", value.ValueString())
+	}
+	return nil
+}
+
+// postgresQuerier is satisfied by both *pgx.Conn and pgx.Tx
+type postgresQuerier interface {
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+}
+
+// postgresRoleOptions renders the WITH options of CREATE ROLE and ALTER ROLE
+func postgresRoleOptions(data *postgresRoleResourceModel) string {
+	options := []string{"NOLOGIN"}
+	if data.Login.ValueBool() {
+		options[0] = "LOGIN"
+	}
+	options = append(options, fmt.Sprintf("CONNECTION LIMIT %d", data.ConnectionLimit.ValueInt64()))
+	if !data.RolePassword.IsNull() {
+		options = append(options, "PASSWORD "+quotePostgresLiteral(data.RolePassword.ValueString()))
+	}
+	if !data.ValidUntil.IsNull() {
+		options = append(options, "VALID UNTIL "+quotePostgresLiteral(data.ValidUntil.ValueString()))
+	}
+	return strings.Join(options, " ")
+}
+
+// grantPostgresRoles grants membership in each of the given roles
+func grantPostgresRoles(ctx context.Context, tx pgx.Tx, roleName string, roles []string) error {
+	for _, role := range roles {
+		tflog.Debug(ctx, "Granting role membership", map[string]interface{}{"role_name": roleName, "member_of": role})
+		stmt := fmt.Sprintf("GRANT %s TO %s", quotePostgresIdentifier(role), quotePostgresIdentifier(roleName))
+		if _, err := tx.Exec(ctx, stmt); err != nil {
+			return fmt.Errorf("error granting %s to %s: %w", role, roleName, err)
+		}
+	}
+	return nil
+}
+
+// postgresRoleMemberships returns the roles the given role is a direct member of
+func postgresRoleMemberships(ctx context.Context, q postgresQuerier, roleName string) ([]string, error) {
+	rows, err := q.Query(ctx, `SELECT g.rolname
+		FROM pg_auth_members m
+		JOIN pg_roles g ON g.oid = m.roleid
+		JOIN pg_roles u ON u.oid = m.member
+		WHERE u.rolname = $1
+		ORDER BY g.rolname`, roleName)
+	if err != nil {
+		return nil, fmt.Errorf("error querying memberships of role %s: %w", roleName, err)
+	}
+
+	memberOf, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return nil, fmt.Errorf("error reading memberships of role %s: %w", roleName, err)
+	}
+	return memberOf, nil
+}
+
+// refreshValidUntil keeps the configured valid_until string when it denotes the same point in time
+func refreshValidUntil(current types.String, actual pgtype.Timestamptz) types.String {
+	// A role without expiry reports infinity, which matches an unset valid_until
+	if current.IsNull() && actual.InfinityModifier == pgtype.Infinity {
+		return current
+	}
+
+	if !current.IsNull() && actual.Valid && actual.InfinityModifier == pgtype.Finite {
+		if parsed, err := time.Parse(time.RFC3339, current.ValueString()); err == nil && parsed.Equal(actual.Time) {
+			return current
+		}
+	}
+	return formatPostgresTimestamp(actual)
+}
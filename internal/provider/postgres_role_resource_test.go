@@ -0,0 +1,155 @@
+// Copyright (c) IBM Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func TestPostgresRoleOptions(t *testing.T) {
+	data := &postgresRoleResourceModel{
+		Login:           types.BoolValue(false),
+		ConnectionLimit: types.Int64Value(-1),
+	}
+	if got, want := postgresRoleOptions(data), "NOLOGIN CONNECTION LIMIT -1"; got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+
+	data.Login = types.BoolValue(true)
+	data.ConnectionLimit = types.Int64Value(5)
+	data.RolePassword = types.StringValue("it's")
+	data.ValidUntil = types.StringValue("2030-01-01T00:00:00Z")
+	want := "LOGIN CONNECTION LIMIT 5 PASSWORD 'it''s' VALID UNTIL '2030-01-01T00:00:00Z'"
+	if got := postgresRoleOptions(data); got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
+
+func TestRefreshValidUntil(t *testing.T) {
+	instant := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	actual := pgtype.Timestamptz{Time: instant, Valid: true}
+
+	// Same instant in a different offset keeps the configured string
+	current := types.StringValue("2030-01-01T01:00:00+01:00")
+	if got := refreshValidUntil(current, actual); !got.Equal(current) {
+		t.Errorf("expected %s to be kept, got %s", current, got)
+	}
+
+	if got := refreshValidUntil(types.StringValue("2031-01-01T00:00:00Z"), actual); got.ValueString() != "2030-01-01T00:00:00Z" {
+		t.Errorf("expected drift to be reported, got %s", got)
+	}
+
+	if got := refreshValidUntil(current, pgtype.Timestamptz{}); !got.IsNull() {
+		t.Errorf("expected null, got %s", got)
+	}
+
+	infinity := pgtype.Timestamptz{Valid: true, InfinityModifier: pgtype.Infinity}
+	if got := refreshValidUntil(types.StringNull(), infinity); !got.IsNull() {
+		t.Errorf("expected null for unconfigured infinity, got %s", got)
+	}
+	if got := refreshValidUntil(current, infinity); got.ValueString() != "infinity" {
+		t.Errorf("expected infinity, got %s", got)
+	}
+}
+
+func TestParsePostgresRoleImportID(t *testing.T) {
+	secretARN := "arn:aws:secretsmanager:us-east-1:123456789012:secret:orders-pg-AbCdEf"
+
+	roleName, arn, sslMode, err := parsePostgresRoleImportID("guardium_collector," + secretARN)
+	if err != nil || roleName != "guardium_collector" || arn != secretARN || sslMode != "" {
+		t.Errorf("unexpected result %q %q %q (%v)", roleName, arn, sslMode, err)
+	}
+
+	if _, _, sslMode, err := parsePostgresRoleImportID("guardium_collector," + secretARN + ",verify-full"); err != nil || sslMode != "verify-full" {
+		t.Errorf("expected ssl_mode verify-full, got %q (%v)", sslMode, err)
+	}
+
+	for _, id := range []string{"guardium_collector", ",", "guardium_collector,", "a,b,c,d"} {
+		if _, _, _, err := parsePostgresRoleImportID(id); err == nil {
+			t.Errorf("expected an error for %q", id)
+		}
+	}
+}
+
+func TestCheckValidUntil(t *testing.T) {
+	for _, value := range []types.String{types.StringNull(), types.StringValue("infinity"), types.StringValue("2030-01-01T01:00:00+01:00")} {
+		if err := checkValidUntil(value); err != nil {
+			t.Errorf("unexpected error for %s: %s", value, err)
+		}
+	}
+	for _, value := range []string{"2030-01-01", "2030-01-01 00:00:00", "tomorrow"} {
+		if err := checkValidUntil(types.StringValue(value)); err == nil {
+			t.Errorf("expected an error for %q", value)
+		}
+	}
+}
+
+func TestPostgresRolePortFromCredentialsSecret(t *testing.T) {
+	// The port has no schema default, so an unset port is unknown in the plan
+	var schemaResp resource.SchemaResponse
+	NewPostgresRoleResource().Schema(context.Background(), resource.SchemaRequest{}, &schemaResp)
+	if port := schemaResp.Schema.Attributes["port"].(schema.StringAttribute); port.Default != nil || !port.Computed {
+		t.Fatalf("expected a computed port without default, got %+v", port)
+	}
+
+	// A closed local port makes the connection fail fast once the port is resolved
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("unable to listen: %s", err)
+	}
+	_, secretPort, _ := net.SplitHostPort(listener.Addr().String())
+	listener.Close()
+
+	secretsManager := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.Copy(io.Discard, r.Body)
+		w.Header().Set("Content-Type", "application/x-amz-json-1.1")
+		secret := fmt.Sprintf(`{"username":"postgres","password":"secret","host":"127.0.0.1","port":%s,"dbname":"orders"}`, secretPort)
+		fmt.Fprintf(w, `{"Name":"orders-pg","SecretString":%q}`, secret)
+	}))
+	defer secretsManager.Close()
+
+	pd := &providerData{
+		awsConfig: aws.Config{
+			Region: "us-east-1",
+			Credentials: aws.CredentialsProviderFunc(func(context.Context) (aws.Credentials, error) {
+				return aws.Credentials{AccessKeyID: "AKIDEXAMPLE", SecretAccessKey: "secret"}, nil
+			}),
+		},
+		endpoints: endpointsData{SecretsManager: secretsManager.URL},
+	}
+
+	plan := postgresRoleResourceModel{
+		postgresConnectionModel: postgresConnectionModel{
+			Port:                 types.StringUnknown(),
+			CredentialsSecretARN: types.StringValue("arn:aws:secretsmanager:us-east-1:123456789012:secret:orders-pg-AbCdEf"),
+		},
+		RoleName: types.StringValue("guardium_collector"),
+	}
+	if _, err := plan.connect(context.Background(), pd); err == nil {
+		t.Fatal("expected the connection to the closed port to fail")
+	}
+	if plan.Port.ValueString() != secretPort {
+		t.Errorf("expected the secret port %s, got %s", secretPort, plan.Port)
+	}
+
+	// A configured port takes precedence over the secret
+	plan.Port = types.StringValue("6543")
+	plan.connect(context.Background(), pd)
+	if plan.Port.ValueString() != "6543" {
+		t.Errorf("expected the configured port, got %s", plan.Port)
+	}
+}
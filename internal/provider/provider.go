@@ -5,11 +5,20 @@ package provider
 
 import (
 	"context"
+	"fmt"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials/stscreds"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
@@ -24,7 +33,32 @@ type GDPMiddlewareHelperProvider struct {
 	version string
 }
 
-type gdpMiddlewareHelperModel struct{}
+type gdpMiddlewareHelperModel struct {
+	Region            types.String     `tfsdk:"region"`
+	Profile           types.String     `tfsdk:"profile"`
+	SharedConfigFiles types.List       `tfsdk:"shared_config_files"`
+	AssumeRole        *assumeRoleModel `tfsdk:"assume_role"`
+	Endpoints         *endpointsModel  `tfsdk:"endpoints"`
+}
+
+// assumeRoleModel maps the assume_role block of the provider configuration.
+type assumeRoleModel struct {
+	RoleARN     types.String `tfsdk:"role_arn"`
+	SessionName types.String `tfsdk:"session_name"`
+	ExternalID  types.String `tfsdk:"external_id"`
+}
+
+// endpointsModel maps the endpoints block of the provider configuration.
+type endpointsModel struct {
+	RDS            types.String `tfsdk:"rds"`
+	Neptune        types.String `tfsdk:"neptune"`
+	DocDB          types.String `tfsdk:"docdb"`
+	OpenSearch     types.String `tfsdk:"opensearch"`
+	Lambda         types.String `tfsdk:"lambda"`
+	SecretsManager types.String `tfsdk:"secretsmanager"`
+	SSM            types.String `tfsdk:"ssm"`
+	STS            types.String `tfsdk:"sts"`
+}
 
 func (p *GDPMiddlewareHelperProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
 	resp.TypeName = "gdp-middleware-helper"
@@ -34,7 +68,77 @@ func (p *GDPMiddlewareHelperProvider) Metadata(ctx context.Context, req provider
 func (p *GDPMiddlewareHelperProvider) Schema(ctx context.Context, req provider.SchemaRequest, resp *provider.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description: "The GDP Middleware Helper provider is used to interact with various middleware services.",
-		Attributes:  map[string]schema.Attribute{},
+		Attributes: map[string]schema.Attribute{
+			"region": schema.StringAttribute{
+				Description: "Default AWS region. Resources and data sources with their own region attribute override it.",
+				Optional:    true,
+			},
+			"profile": schema.StringAttribute{
+				Description: "Named profile from the shared AWS config and credentials files.",
+				Optional:    true,
+			},
+			"shared_config_files": schema.ListAttribute{
+				Description: "List of paths to shared AWS config files. Defaults to the SDK search path.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"assume_role": schema.SingleNestedBlock{
+				Description: "IAM role to assume for all AWS API calls made by the provider.",
+				Attributes: map[string]schema.Attribute{
+					"role_arn": schema.StringAttribute{
+						Description: "ARN of the IAM role to assume. Required when the assume_role block is present.",
+						Optional:    true,
+					},
+					"session_name": schema.StringAttribute{
+						Description: "Session name to use when assuming the role.",
+						Optional:    true,
+					},
+					"external_id": schema.StringAttribute{
+						Description: "External identifier to use when assuming the role.",
+						Optional:    true,
+					},
+				},
+			},
+			"endpoints": schema.SingleNestedBlock{
+				Description: "Custom endpoint overrides for individual AWS services.",
+				Attributes: map[string]schema.Attribute{
+					"rds": schema.StringAttribute{
+						Description: "Custom endpoint for the RDS API (also used for Aurora).",
+						Optional:    true,
+					},
+					"neptune": schema.StringAttribute{
+						Description: "Custom endpoint for the Neptune API.",
+						Optional:    true,
+					},
+					"docdb": schema.StringAttribute{
+						Description: "Custom endpoint for the DocumentDB API.",
+						Optional:    true,
+					},
+					"opensearch": schema.StringAttribute{
+						Description: "Custom endpoint for the OpenSearch Service API.",
+						Optional:    true,
+					},
+					"lambda": schema.StringAttribute{
+						Description: "Custom endpoint for the Lambda API.",
+						Optional:    true,
+					},
+					"secretsmanager": schema.StringAttribute{
+						Description: "Custom endpoint for the Secrets Manager API used to read database credentials.",
+						Optional:    true,
+					},
+					"ssm": schema.StringAttribute{
+						Description: "Custom endpoint for the SSM API used to track asynchronous Lambda invocations.",
+						Optional:    true,
+					},
+					"sts": schema.StringAttribute{
+						Description: "Custom endpoint for the STS API used to assume roles.",
+						Optional:    true,
+					},
+				},
+			},
+		},
 	}
 }
 
@@ -49,28 +153,153 @@ func (p *GDPMiddlewareHelperProvider) Configure(ctx context.Context, req provide
 		return
 	}
 
-	// No configuration needed for this provider
-	resp.DataSourceData = struct{}{}
-	resp.ResourceData = struct{}{}
+	// Values computed from other resources are unknown until apply; reject them
+	// instead of silently falling back to the SDK defaults
+	validateKnownProviderValues(&data, &resp.Diagnostics)
+	if data.AssumeRole != nil && data.AssumeRole.RoleARN.ValueString() == "" && !data.AssumeRole.RoleARN.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("assume_role").AtName("role_arn"),
+			"Missing assume_role role_arn",
+			"The assume_role block requires role_arn. Without it the provider would use the base credentials instead of the intended role.",
+		)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var loadOptions []func(*config.LoadOptions) error
+	if !data.Region.IsNull() {
+		loadOptions = append(loadOptions, config.WithRegion(data.Region.ValueString()))
+	}
+	if !data.Profile.IsNull() {
+		loadOptions = append(loadOptions, config.WithSharedConfigProfile(data.Profile.ValueString()))
+	}
+	if !data.SharedConfigFiles.IsNull() {
+		var files []string
+		resp.Diagnostics.Append(data.SharedConfigFiles.ElementsAs(ctx, &files, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		loadOptions = append(loadOptions, config.WithSharedConfigFiles(files))
+	}
+
+	awsCfg, err := config.LoadDefaultConfig(ctx, loadOptions...)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to load AWS SDK config", fmt.Sprintf("Unable to load AWS SDK config: %s", err))
+		return
+	}
+
+	endpoints := endpointsData{}
+	if data.Endpoints != nil {
+		endpoints = endpointsData{
+			RDS:            data.Endpoints.RDS.ValueString(),
+			Neptune:        data.Endpoints.Neptune.ValueString(),
+			DocDB:          data.Endpoints.DocDB.ValueString(),
+			OpenSearch:     data.Endpoints.OpenSearch.ValueString(),
+			Lambda:         data.Endpoints.Lambda.ValueString(),
+			SecretsManager: data.Endpoints.SecretsManager.ValueString(),
+			SSM:            data.Endpoints.SSM.ValueString(),
+			STS:            data.Endpoints.STS.ValueString(),
+		}
+	}
+
+	// Swap the credentials for the assumed role if one is configured
+	if data.AssumeRole != nil {
+		tflog.Debug(ctx, "configuring assume role credentials", map[string]interface{}{
+			"role_arn": data.AssumeRole.RoleARN.ValueString(),
+		})
+
+		stsClient := sts.NewFromConfig(awsCfg, func(o *sts.Options) {
+			if endpoints.STS != "" {
+				o.BaseEndpoint = aws.String(endpoints.STS)
+			}
+		})
+		assumeRole := data.AssumeRole
+		awsCfg.Credentials = aws.NewCredentialsCache(stscreds.NewAssumeRoleProvider(stsClient, assumeRole.RoleARN.ValueString(), func(o *stscreds.AssumeRoleOptions) {
+			if !assumeRole.SessionName.IsNull() {
+				o.RoleSessionName = assumeRole.SessionName.ValueString()
+			}
+			if !assumeRole.ExternalID.IsNull() {
+				o.ExternalID = aws.String(assumeRole.ExternalID.ValueString())
+			}
+		}))
+	}
+
+	pd := &providerData{
+		awsConfig: awsCfg,
+		endpoints: endpoints,
+	}
+	resp.DataSourceData = pd
+	resp.ResourceData = pd
 	tflog.Info(ctx, "provider configuration complete")
 }
 
+// validateKnownProviderValues reports an error for every provider attribute whose value is not known at configure time
+func validateKnownProviderValues(data *gdpMiddlewareHelperModel, diags *diag.Diagnostics) {
+	values := map[string]attr.Value{
+		"region":              data.Region,
+		"profile":             data.Profile,
+		"shared_config_files": data.SharedConfigFiles,
+	}
+	for name, value := range values {
+		checkKnownProviderValue(path.Root(name), value, diags)
+	}
+
+	if data.AssumeRole != nil {
+		block := path.Root("assume_role")
+		checkKnownProviderValue(block.AtName("role_arn"), data.AssumeRole.RoleARN, diags)
+		checkKnownProviderValue(block.AtName("session_name"), data.AssumeRole.SessionName, diags)
+		checkKnownProviderValue(block.AtName("external_id"), data.AssumeRole.ExternalID, diags)
+	}
+
+	if data.Endpoints != nil {
+		block := path.Root("endpoints")
+		checkKnownProviderValue(block.AtName("rds"), data.Endpoints.RDS, diags)
+		checkKnownProviderValue(block.AtName("neptune"), data.Endpoints.Neptune, diags)
+		checkKnownProviderValue(block.AtName("docdb"), data.Endpoints.DocDB, diags)
+		checkKnownProviderValue(block.AtName("opensearch"), data.Endpoints.OpenSearch, diags)
+		checkKnownProviderValue(block.AtName("lambda"), data.Endpoints.Lambda, diags)
+		checkKnownProviderValue(block.AtName("secretsmanager"), data.Endpoints.SecretsManager, diags)
+		checkKnownProviderValue(block.AtName("ssm"), data.Endpoints.SSM, diags)
+		checkKnownProviderValue(block.AtName("sts"), data.Endpoints.STS, diags)
+	}
+}
+
+func checkKnownProviderValue(p path.Path, value attr.Value, diags *diag.Diagnostics) {
+	if !value.IsUnknown() {
+		return
+	}
+	diags.AddAttributeError(
+		p,
+		"Unknown provider configuration value",
+		fmt.Sprintf("The provider cannot create the AWS configuration as there is an unknown value for %s. "+
+			"Set the value statically in the configuration or use a variable.", p),
+	)
+}
+
 func (p *GDPMiddlewareHelperProvider) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		NewExecuteAwsLambdaFunctionResource,
 		NewRDSRebootResource,
 		NewAuroraRebootResource,
 		NewNeptuneRebootResource,
+		NewDocDBRebootResource,
 		NewRDSModifyResource,
 		NewAuroraModifyResource,
 		NewNeptuneModifyResource,
+		NewDocDBModifyResource,
+		NewDBParameterGroupAuditResource,
+		NewRDSMySQLAuditPluginResource,
 		NewOpenSearchModifyResource,
+		NewPostgresPgauditResource,
+		NewPostgresRoleResource,
 	}
 }
 
 func (p *GDPMiddlewareHelperProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
 		NewPostgresRoleCheckDataSource,
+		NewMySQLAuditCheckDataSource,
 		NewDocDBParameterGroupDataSource,
 		NewRDSPostgresParameterGroupDataSource,
 		NewRDSMariaDBDataSource,
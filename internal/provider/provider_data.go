@@ -0,0 +1,190 @@
+// Copyright (c) IBM Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/docdb"
+	lambdasvc "github.com/aws/aws-sdk-go-v2/service/lambda"
+	"github.com/aws/aws-sdk-go-v2/service/neptune"
+	"github.com/aws/aws-sdk-go-v2/service/opensearch"
+	"github.com/aws/aws-sdk-go-v2/service/rds"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// providerData is built once in the provider Configure and handed to every
+// resource and data source through ResourceData/DataSourceData.
+type providerData struct {
+	awsConfig aws.Config
+	endpoints endpointsData
+
+	// clients caches service clients by service and region. Resources are
+	// configured concurrently, so access is guarded by clientsMu.
+	clientsMu sync.Mutex
+	clients   map[clientKey]any
+}
+
+// clientKey identifies a cached service client
+type clientKey struct {
+	service string
+	region  string
+}
+
+// endpointsData holds the per-service endpoint overrides. Empty values use the
+// SDK default endpoint resolution.
+type endpointsData struct {
+	RDS            string
+	Neptune        string
+	DocDB          string
+	OpenSearch     string
+	Lambda         string
+	SecretsManager string
+	SSM            string
+	STS            string
+}
+
+// providerDataFrom extracts the provider data passed to a resource or data source Configure
+func providerDataFrom(data any, diags *diag.Diagnostics) *providerData {
+	pd, ok := data.(*providerData)
+	if !ok {
+		diags.AddError(
+			"Unexpected Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", data),
+		)
+		return nil
+	}
+	return pd
+}
+
+// configured reports an error when a resource or data source is used before the
+// provider data has been handed over by Configure
+func (p *providerData) configured(diags *diag.Diagnostics) bool {
+	if p == nil {
+		diags.AddError(
+			"Unconfigured Provider",
+			"The provider has not been configured, so no AWS client is available. Please report this issue to the provider developers.",
+		)
+		return false
+	}
+	return true
+}
+
+// regionalConfig returns a copy of the provider AWS config, overriding the region when one is given
+func (p *providerData) regionalConfig(region string) aws.Config {
+	cfg := p.awsConfig.Copy()
+	if region != "" {
+		cfg.Region = region
+	}
+	return cfg
+}
+
+func (p *providerData) rdsOptions(o *rds.Options) {
+	if p.endpoints.RDS != "" {
+		o.BaseEndpoint = aws.String(p.endpoints.RDS)
+	}
+}
+
+func (p *providerData) neptuneOptions(o *neptune.Options) {
+	if p.endpoints.Neptune != "" {
+		o.BaseEndpoint = aws.String(p.endpoints.Neptune)
+	}
+}
+
+func (p *providerData) docdbOptions(o *docdb.Options) {
+	if p.endpoints.DocDB != "" {
+		o.BaseEndpoint = aws.String(p.endpoints.DocDB)
+	}
+}
+
+func (p *providerData) opensearchOptions(o *opensearch.Options) {
+	if p.endpoints.OpenSearch != "" {
+		o.BaseEndpoint = aws.String(p.endpoints.OpenSearch)
+	}
+}
+
+func (p *providerData) lambdaOptions(o *lambdasvc.Options) {
+	if p.endpoints.Lambda != "" {
+		o.BaseEndpoint = aws.String(p.endpoints.Lambda)
+	}
+}
+
+func (p *providerData) secretsmanagerOptions(o *secretsmanager.Options) {
+	if p.endpoints.SecretsManager != "" {
+		o.BaseEndpoint = aws.String(p.endpoints.SecretsManager)
+	}
+}
+
+func (p *providerData) ssmOptions(o *ssm.Options) {
+	if p.endpoints.SSM != "" {
+		o.BaseEndpoint = aws.String(p.endpoints.SSM)
+	}
+}
+
+// cachedClient returns the client for the service and region, building and caching it on first use.
+// An empty region resolves to the provider region.
+func cachedClient[T any](p *providerData, service, region string, build func(cfg aws.Config) T) T {
+	cfg := p.regionalConfig(region)
+	key := clientKey{service: service, region: cfg.Region}
+
+	p.clientsMu.Lock()
+	defer p.clientsMu.Unlock()
+
+	if client, ok := p.clients[key]; ok {
+		return client.(T)
+	}
+
+	client := build(cfg)
+	if p.clients == nil {
+		p.clients = make(map[clientKey]any)
+	}
+	p.clients[key] = client
+	return client
+}
+
+func (p *providerData) rdsClient(region string) *rds.Client {
+	return cachedClient(p, "rds", region, func(cfg aws.Config) *rds.Client {
+		return rds.NewFromConfig(cfg, p.rdsOptions)
+	})
+}
+
+func (p *providerData) neptuneClient(region string) *neptune.Client {
+	return cachedClient(p, "neptune", region, func(cfg aws.Config) *neptune.Client {
+		return neptune.NewFromConfig(cfg, p.neptuneOptions)
+	})
+}
+
+func (p *providerData) docdbClient(region string) *docdb.Client {
+	return cachedClient(p, "docdb", region, func(cfg aws.Config) *docdb.Client {
+		return docdb.NewFromConfig(cfg, p.docdbOptions)
+	})
+}
+
+func (p *providerData) opensearchClient(region string) *opensearch.Client {
+	return cachedClient(p, "opensearch", region, func(cfg aws.Config) *opensearch.Client {
+		return opensearch.NewFromConfig(cfg, p.opensearchOptions)
+	})
+}
+
+func (p *providerData) lambdaClient(region string) *lambdasvc.Client {
+	return cachedClient(p, "lambda", region, func(cfg aws.Config) *lambdasvc.Client {
+		return lambdasvc.NewFromConfig(cfg, p.lambdaOptions)
+	})
+}
+
+func (p *providerData) secretsmanagerClient(region string) *secretsmanager.Client {
+	return cachedClient(p, "secretsmanager", region, func(cfg aws.Config) *secretsmanager.Client {
+		return secretsmanager.NewFromConfig(cfg, p.secretsmanagerOptions)
+	})
+}
+
+func (p *providerData) ssmClient(region string) *ssm.Client {
+	return cachedClient(p, "ssm", region, func(cfg aws.Config) *ssm.Client {
+		return ssm.NewFromConfig(cfg, p.ssmOptions)
+	})
+}
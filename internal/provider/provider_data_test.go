@@ -0,0 +1,89 @@
+// Copyright (c) IBM Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+func TestProviderDataFrom(t *testing.T) {
+	var diags diag.Diagnostics
+	if pd := providerDataFrom(struct{}{}, &diags); pd != nil {
+		t.Fatalf("expected nil provider data for unexpected type, got %#v", pd)
+	}
+	if !diags.HasError() {
+		t.Fatal("expected an error diagnostic for unexpected provider data type")
+	}
+
+	diags = nil
+	want := &providerData{}
+	if got := providerDataFrom(want, &diags); got != want {
+		t.Fatalf("expected %p, got %p", want, got)
+	}
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+}
+
+func TestProviderDataConfigured(t *testing.T) {
+	var diags diag.Diagnostics
+	var pd *providerData
+	if pd.configured(&diags) {
+		t.Fatal("expected nil provider data to be reported as unconfigured")
+	}
+	if !diags.HasError() {
+		t.Fatal("expected an error diagnostic for nil provider data")
+	}
+}
+
+func TestRegionalConfig(t *testing.T) {
+	pd := &providerData{awsConfig: aws.Config{Region: "us-east-1"}}
+
+	cfg := pd.regionalConfig("eu-west-1")
+	if cfg.Region != "eu-west-1" {
+		t.Errorf("expected overridden region eu-west-1, got %q", cfg.Region)
+	}
+	if pd.awsConfig.Region != "us-east-1" {
+		t.Errorf("override must not change the provider config, got %q", pd.awsConfig.Region)
+	}
+
+	cfg = pd.regionalConfig("")
+	if cfg.Region != "us-east-1" {
+		t.Errorf("expected provider region us-east-1, got %q", cfg.Region)
+	}
+}
+
+func TestCachedClients(t *testing.T) {
+	pd := &providerData{awsConfig: aws.Config{Region: "us-east-1"}}
+
+	if pd.rdsClient("") != pd.rdsClient("us-east-1") {
+		t.Error("expected the default region and the provider region to share a client")
+	}
+	if pd.rdsClient("us-east-1") == pd.rdsClient("eu-west-1") {
+		t.Error("expected separate clients per region")
+	}
+	if got := len(pd.clients); got != 2 {
+		t.Errorf("expected 2 cached clients, got %d", got)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			pd.neptuneClient("ap-south-1")
+		}()
+	}
+	wg.Wait()
+	if pd.neptuneClient("ap-south-1") == nil {
+		t.Fatal("expected a neptune client")
+	}
+	if got := len(pd.clients); got != 3 {
+		t.Errorf("expected 3 cached clients, got %d", got)
+	}
+}
@@ -0,0 +1,167 @@
+// Copyright (c) IBM Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials/stscreds"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// testProviderConfig builds a provider configuration from the given top level values, leaving everything else null
+func testProviderConfig(t *testing.T, values map[string]tftypes.Value) (tfsdk.Config, tftypes.Object) {
+	t.Helper()
+
+	var schemaResp provider.SchemaResponse
+	(&GDPMiddlewareHelperProvider{}).Schema(context.Background(), provider.SchemaRequest{}, &schemaResp)
+
+	typ := schemaResp.Schema.Type().TerraformType(context.Background()).(tftypes.Object)
+	return tfsdk.Config{Schema: schemaResp.Schema, Raw: testObjectValue(typ, values)}, typ
+}
+
+// testObjectValue builds an object value of the given type, filling unset attributes with null
+func testObjectValue(typ tftypes.Object, values map[string]tftypes.Value) tftypes.Value {
+	attrs := make(map[string]tftypes.Value, len(typ.AttributeTypes))
+	for name, attrType := range typ.AttributeTypes {
+		if v, ok := values[name]; ok {
+			attrs[name] = v
+		} else {
+			attrs[name] = tftypes.NewValue(attrType, nil)
+		}
+	}
+	return tftypes.NewValue(typ, attrs)
+}
+
+func testConfigureProvider(t *testing.T, values func(typ tftypes.Object) map[string]tftypes.Value) provider.ConfigureResponse {
+	t.Helper()
+
+	// Keep the SDK away from the credentials and config of the machine running the tests
+	t.Setenv("AWS_CONFIG_FILE", filepath.Join(t.TempDir(), "config"))
+	t.Setenv("AWS_SHARED_CREDENTIALS_FILE", filepath.Join(t.TempDir(), "credentials"))
+	t.Setenv("AWS_ACCESS_KEY_ID", "AKIDTEST")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "secret")
+	t.Setenv("AWS_PROFILE", "")
+
+	_, typ := testProviderConfig(t, nil)
+	config, _ := testProviderConfig(t, values(typ))
+
+	var resp provider.ConfigureResponse
+	(&GDPMiddlewareHelperProvider{}).Configure(context.Background(), provider.ConfigureRequest{Config: config}, &resp)
+	return resp
+}
+
+func TestProviderConfigure(t *testing.T) {
+	resp := testConfigureProvider(t, func(typ tftypes.Object) map[string]tftypes.Value {
+		return map[string]tftypes.Value{
+			"region": tftypes.NewValue(tftypes.String, "eu-central-1"),
+		}
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	pd, ok := resp.ResourceData.(*providerData)
+	if !ok {
+		t.Fatalf("expected *providerData resource data, got %T", resp.ResourceData)
+	}
+	if resp.DataSourceData != resp.ResourceData {
+		t.Error("expected resources and data sources to share the provider data")
+	}
+	if pd.awsConfig.Region != "eu-central-1" {
+		t.Errorf("expected region eu-central-1, got %q", pd.awsConfig.Region)
+	}
+	if aws.IsCredentialsProvider(pd.awsConfig.Credentials, (*stscreds.AssumeRoleProvider)(nil)) {
+		t.Error("did not expect assume role credentials without an assume_role block")
+	}
+}
+
+func TestProviderConfigure_AssumeRole(t *testing.T) {
+	resp := testConfigureProvider(t, func(typ tftypes.Object) map[string]tftypes.Value {
+		assumeRoleType := typ.AttributeTypes["assume_role"].(tftypes.Object)
+		return map[string]tftypes.Value{
+			"region": tftypes.NewValue(tftypes.String, "us-east-1"),
+			"assume_role": testObjectValue(assumeRoleType, map[string]tftypes.Value{
+				"role_arn":     tftypes.NewValue(tftypes.String, "arn:aws:iam::123456789012:role/guardium"),
+				"session_name": tftypes.NewValue(tftypes.String, "onboarding"),
+			}),
+		}
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	pd := resp.ResourceData.(*providerData)
+	if !aws.IsCredentialsProvider(pd.awsConfig.Credentials, (*stscreds.AssumeRoleProvider)(nil)) {
+		t.Errorf("expected assume role credentials, got %T", pd.awsConfig.Credentials)
+	}
+}
+
+func TestProviderConfigure_AssumeRoleWithoutRoleARN(t *testing.T) {
+	resp := testConfigureProvider(t, func(typ tftypes.Object) map[string]tftypes.Value {
+		assumeRoleType := typ.AttributeTypes["assume_role"].(tftypes.Object)
+		return map[string]tftypes.Value{
+			"assume_role": testObjectValue(assumeRoleType, map[string]tftypes.Value{
+				"session_name": tftypes.NewValue(tftypes.String, "onboarding"),
+			}),
+		}
+	})
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected an error when assume_role has no role_arn")
+	}
+	if resp.ResourceData != nil {
+		t.Error("expected no provider data when configuration fails")
+	}
+}
+
+func TestProviderConfigure_Endpoints(t *testing.T) {
+	resp := testConfigureProvider(t, func(typ tftypes.Object) map[string]tftypes.Value {
+		endpointsType := typ.AttributeTypes["endpoints"].(tftypes.Object)
+		return map[string]tftypes.Value{
+			"region": tftypes.NewValue(tftypes.String, "us-east-1"),
+			"endpoints": testObjectValue(endpointsType, map[string]tftypes.Value{
+				"rds":            tftypes.NewValue(tftypes.String, "http://localhost:4566"),
+				"lambda":         tftypes.NewValue(tftypes.String, "http://localhost:4567"),
+				"secretsmanager": tftypes.NewValue(tftypes.String, "http://localhost:4568"),
+			}),
+		}
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	pd := resp.ResourceData.(*providerData)
+	if pd.endpoints.RDS != "http://localhost:4566" {
+		t.Errorf("expected rds endpoint override, got %q", pd.endpoints.RDS)
+	}
+	if pd.endpoints.Lambda != "http://localhost:4567" {
+		t.Errorf("expected lambda endpoint override, got %q", pd.endpoints.Lambda)
+	}
+	if pd.endpoints.SecretsManager != "http://localhost:4568" {
+		t.Errorf("expected secretsmanager endpoint override, got %q", pd.endpoints.SecretsManager)
+	}
+	if pd.endpoints.Neptune != "" {
+		t.Errorf("expected no neptune endpoint override, got %q", pd.endpoints.Neptune)
+	}
+}
+
+func TestProviderConfigure_UnknownValues(t *testing.T) {
+	resp := testConfigureProvider(t, func(typ tftypes.Object) map[string]tftypes.Value {
+		endpointsType := typ.AttributeTypes["endpoints"].(tftypes.Object)
+		return map[string]tftypes.Value{
+			"region": tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+			"endpoints": testObjectValue(endpointsType, map[string]tftypes.Value{
+				"rds": tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+			}),
+		}
+	})
+	if got := resp.Diagnostics.ErrorsCount(); got != 2 {
+		t.Fatalf("expected 2 errors for unknown region and endpoint, got %d: %v", got, resp.Diagnostics)
+	}
+}
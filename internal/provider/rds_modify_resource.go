@@ -9,9 +9,9 @@ import (
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
-	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/rds"
 	"github.com/aws/aws-sdk-go-v2/service/rds/types"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -33,7 +33,7 @@ func NewRDSModifyResource() resource.Resource {
 
 // RDSModifyResource defines the resource implementation.
 type RDSModifyResource struct {
-	client *rds.Client
+	providerData *providerData
 }
 
 // RDSModifyResourceModel describes the resource data model.
@@ -46,6 +46,7 @@ type RDSModifyResourceModel struct {
 	ApplyImmediately      frameworktypes.Bool   `tfsdk:"apply_immediately"`
 	LastModifiedTime      frameworktypes.String `tfsdk:"last_modified_time"`
 	ID                    frameworktypes.String `tfsdk:"id"`
+	Timeouts              timeouts.Value        `tfsdk:"timeouts"`
 }
 
 func (r *RDSModifyResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -104,6 +105,12 @@ func (r *RDSModifyResource) Schema(ctx context.Context, req resource.SchemaReque
 				},
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Update: true,
+			}),
+		},
 	}
 }
 
@@ -115,14 +122,13 @@ func (r *RDSModifyResource) Configure(ctx context.Context, req resource.Configur
 		return
 	}
 
-	// Create AWS config and RDS client
-	awsCfg, err := config.LoadDefaultConfig(ctx)
-	if err != nil {
-		resp.Diagnostics.AddError("Unable to load AWS SDK config", fmt.Sprintf("Unable to load AWS SDK config: %s", err))
+	// Keep the provider data, RDS clients are built per region on first use
+	pd := providerDataFrom(req.ProviderData, &resp.Diagnostics)
+	if pd == nil {
 		return
 	}
 
-	r.client = rds.NewFromConfig(awsCfg)
+	r.providerData = pd
 }
 
 func (r *RDSModifyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -134,21 +140,22 @@ func (r *RDSModifyResource) Create(ctx context.Context, req resource.CreateReque
 		return
 	}
 
-	// If region is specified, update the AWS config
-	var client *rds.Client
-	if !data.Region.IsNull() {
-		tflog.Debug(ctx, "configuring client with region")
-		awsCfg, err := config.LoadDefaultConfig(ctx, config.WithRegion(data.Region.ValueString()))
-		if err != nil {
-			resp.Diagnostics.AddError("Unable to load AWS SDK config", fmt.Sprintf("Unable to load AWS SDK config with region %s: %s", data.Region.ValueString(), err))
-			return
-		}
-		client = rds.NewFromConfig(awsCfg)
-	} else {
-		tflog.Debug(ctx, "using default client")
-		client = r.client
+	if !r.providerData.configured(&resp.Diagnostics) {
+		return
+	}
+
+	timeout, diags := data.Timeouts.Create(ctx, defaultWaitTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	// Get the cached client for the requested region
+	client := r.providerData.rdsClient(data.Region.ValueString())
+
 	// Prepare modify input
 	input := &rds.ModifyDBInstanceInput{
 		DBInstanceIdentifier: aws.String(data.DBInstanceIdentifier.ValueString()),
@@ -202,7 +209,7 @@ func (r *RDSModifyResource) Create(ctx context.Context, req resource.CreateReque
 	}
 
 	tflog.Info(ctx, "Waiting for RDS instance to become available after modification")
-	err = waiter.Wait(ctx, waitInput, 30*time.Minute)
+	err = waiter.Wait(ctx, waitInput, timeout)
 	if err != nil {
 		resp.Diagnostics.AddError("Error waiting for RDS instance to become available", fmt.Sprintf("Could not confirm RDS instance availability: %s", err))
 		return
@@ -226,21 +233,13 @@ func (r *RDSModifyResource) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 
-	// If region is specified, update the AWS config
-	var client *rds.Client
-	if !data.Region.IsNull() {
-		tflog.Debug(ctx, "configuring client with region")
-		awsCfg, err := config.LoadDefaultConfig(ctx, config.WithRegion(data.Region.ValueString()))
-		if err != nil {
-			resp.Diagnostics.AddError("Unable to load AWS SDK config", fmt.Sprintf("Unable to load AWS SDK config with region %s: %s", data.Region.ValueString(), err))
-			return
-		}
-		client = rds.NewFromConfig(awsCfg)
-	} else {
-		tflog.Debug(ctx, "using default client")
-		client = r.client
+	if !r.providerData.configured(&resp.Diagnostics) {
+		return
 	}
 
+	// Get the cached client for the requested region
+	client := r.providerData.rdsClient(data.Region.ValueString())
+
 	// Check if the RDS instance exists
 	input := &rds.DescribeDBInstancesInput{
 		DBInstanceIdentifier: aws.String(data.DBInstanceIdentifier.ValueString()),
@@ -265,21 +264,22 @@ func (r *RDSModifyResource) Update(ctx context.Context, req resource.UpdateReque
 		return
 	}
 
-	// If region is specified, update the AWS config
-	var client *rds.Client
-	if !data.Region.IsNull() {
-		tflog.Debug(ctx, "configuring client with region")
-		awsCfg, err := config.LoadDefaultConfig(ctx, config.WithRegion(data.Region.ValueString()))
-		if err != nil {
-			resp.Diagnostics.AddError("Unable to load AWS SDK config", fmt.Sprintf("Unable to load AWS SDK config with region %s: %s", data.Region.ValueString(), err))
-			return
-		}
-		client = rds.NewFromConfig(awsCfg)
-	} else {
-		tflog.Debug(ctx, "using default client")
-		client = r.client
+	if !r.providerData.configured(&resp.Diagnostics) {
+		return
 	}
 
+	timeout, diags := data.Timeouts.Update(ctx, defaultWaitTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	// Get the cached client for the requested region
+	client := r.providerData.rdsClient(data.Region.ValueString())
+
 	// Prepare modify input
 	input := &rds.ModifyDBInstanceInput{
 		DBInstanceIdentifier: aws.String(data.DBInstanceIdentifier.ValueString()),
@@ -333,7 +333,7 @@ func (r *RDSModifyResource) Update(ctx context.Context, req resource.UpdateReque
 	}
 
 	tflog.Debug(ctx, "Waiting for RDS instance to become available after modification")
-	err = waiter.Wait(ctx, waitInput, 30*time.Minute)
+	err = waiter.Wait(ctx, waitInput, timeout)
 	if err != nil {
 		resp.Diagnostics.AddError("Error waiting for RDS instance to become available", fmt.Sprintf("Could not confirm RDS instance availability: %s", err))
 		return
@@ -0,0 +1,562 @@
+// Copyright (c) IBM Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/rds"
+	rdstypes "github.com/aws/aws-sdk-go-v2/service/rds/types"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// mariadbAuditPluginOption is the option group option that installs the MariaDB audit plugin on MySQL and MariaDB
+const mariadbAuditPluginOption = "MARIADB_AUDIT_PLUGIN"
+
+// defaultOptionGroupPrefix starts the name of the option groups provided by AWS, such as default:mysql-8-0
+const defaultOptionGroupPrefix = "default:"
+
+// optionGroupInSync is the option group membership status once the option group changes are applied
+const optionGroupInSync = "in-sync"
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ resource.Resource = &RDSMySQLAuditPluginResource{}
+var _ resource.ResourceWithImportState = &RDSMySQLAuditPluginResource{}
+
+func NewRDSMySQLAuditPluginResource() resource.Resource {
+	return &RDSMySQLAuditPluginResource{}
+}
+
+// RDSMySQLAuditPluginResource defines the resource implementation.
+type RDSMySQLAuditPluginResource struct {
+	providerData *providerData
+}
+
+// RDSMySQLAuditPluginResourceModel describes the resource data model.
+type RDSMySQLAuditPluginResourceModel struct {
+	DBInstanceIdentifier  types.String   `tfsdk:"db_instance_identifier"`
+	Region                types.String   `tfsdk:"region"`
+	TargetOptionGroupName types.String   `tfsdk:"target_option_group_name"`
+	OptionSettings        types.Map      `tfsdk:"option_settings"`
+	ApplyImmediately      types.Bool     `tfsdk:"apply_immediately"`
+	SourceOptionGroupName types.String   `tfsdk:"source_option_group_name"`
+	OptionGroupName       types.String   `tfsdk:"option_group_name"`
+	Copied                types.Bool     `tfsdk:"copied"`
+	MembershipStatus      types.String   `tfsdk:"membership_status"`
+	LastModifiedTime      types.String   `tfsdk:"last_modified_time"`
+	ID                    types.String   `tfsdk:"id"`
+	Timeouts              timeouts.Value `tfsdk:"timeouts"`
+}
+
+func (r *RDSMySQLAuditPluginResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_rds_mysql_audit_plugin"
+}
+
+func (r *RDSMySQLAuditPluginResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Resource for adding the `MARIADB_AUDIT_PLUGIN` option to the option group of an RDS MySQL or MariaDB instance. " +
+			"When the instance uses a `default:` option group, the group is copied and the copy is attached to the instance. The option group is kept on destroy",
+
+		Attributes: map[string]schema.Attribute{
+			"db_instance_identifier": schema.StringAttribute{
+				MarkdownDescription: "The identifier of the RDS MySQL or MariaDB instance",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"region": schema.StringAttribute{
+				MarkdownDescription: "AWS region where the RDS instance is located",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"target_option_group_name": schema.StringAttribute{
+				MarkdownDescription: "Name of the copy created when the instance uses a `default:` option group. Defaults to `<db_instance_identifier>-audit`",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"option_settings": schema.MapAttribute{
+				MarkdownDescription: "Settings of the audit plugin keyed by name, for example `SERVER_AUDIT_EVENTS` and `SERVER_AUDIT_EXCL_USERS`. Settings that are not listed keep their current value",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+			"apply_immediately": schema.BoolAttribute{
+				MarkdownDescription: "Whether to apply the option group changes immediately and wait for the membership to be `in-sync`, otherwise they are applied during the next maintenance window. Defaults to `true`",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+			},
+			"source_option_group_name": schema.StringAttribute{
+				MarkdownDescription: "Option group the instance used before the audit plugin was configured",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"option_group_name": schema.StringAttribute{
+				MarkdownDescription: "Option group holding the audit plugin, either the copy or the custom group of the instance",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"copied": schema.BoolAttribute{
+				MarkdownDescription: "Whether the option group was copied from a `default:` option group by this resource",
+				Computed:            true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"membership_status": schema.StringAttribute{
+				MarkdownDescription: "Status of the option group membership of the instance, `in-sync` once the changes are applied",
+				Computed:            true,
+			},
+			"last_modified_time": schema.StringAttribute{
+				MarkdownDescription: "Timestamp of the last modification operation",
+				Computed:            true,
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Identifier of the resource",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Update: true,
+			}),
+		},
+	}
+}
+
+func (r *RDSMySQLAuditPluginResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	tflog.Info(ctx, "Configuring RDS MySQL audit plugin resource")
+
+	// If provider is not configured, return
+	if req.ProviderData == nil {
+		return
+	}
+
+	// Keep the provider data, RDS clients are built per region on first use
+	pd := providerDataFrom(req.ProviderData, &resp.Diagnostics)
+	if pd == nil {
+		return
+	}
+
+	r.providerData = pd
+}
+
+func (r *RDSMySQLAuditPluginResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data RDSMySQLAuditPluginResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !r.providerData.configured(&resp.Diagnostics) {
+		return
+	}
+
+	timeout, diags := data.Timeouts.Create(ctx, defaultWaitTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	data.Copied = types.BoolValue(false)
+
+	r.configurePlugin(ctx, &data, timeout, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = types.StringValue(data.DBInstanceIdentifier.ValueString())
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RDSMySQLAuditPluginResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data RDSMySQLAuditPluginResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !r.providerData.configured(&resp.Diagnostics) {
+		return
+	}
+
+	// Get the cached client for the requested region
+	client := r.providerData.rdsClient(data.Region.ValueString())
+
+	instance, err := describeRDSInstance(ctx, client, data.DBInstanceIdentifier.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading RDS instance", fmt.Sprintf("Could not read RDS instance: %s", err))
+		return
+	}
+	if instance == nil {
+		tflog.Warn(ctx, "RDS instance not found, removing audit plugin resource from state", map[string]interface{}{
+			"db_instance_identifier": data.DBInstanceIdentifier.ValueString(),
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// Follow the option group the instance uses now so that the settings below are read from it
+	current, status := optionGroupMembership(*instance, data.OptionGroupName.ValueString())
+	data.MembershipStatus = types.StringValue(status)
+	if current != "" && current != data.OptionGroupName.ValueString() {
+		tflog.Warn(ctx, "RDS instance uses a different option group", map[string]interface{}{
+			"option_group_name": current,
+		})
+		data.OptionGroupName = types.StringValue(current)
+	}
+
+	option, err := describeOptionGroupOption(ctx, client, data.OptionGroupName.ValueString(), mariadbAuditPluginOption)
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading option group", fmt.Sprintf("Could not read option group %s: %s", data.OptionGroupName.ValueString(), err))
+		return
+	}
+
+	// Refresh the configured settings so that changes made outside of Terraform show up as a diff
+	if !data.OptionSettings.IsNull() || option == nil {
+		var wanted map[string]string
+		resp.Diagnostics.Append(data.OptionSettings.ElementsAs(ctx, &wanted, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		actual := map[string]string{}
+		if option != nil {
+			actual = optionSettingValues(*option, wanted)
+		} else {
+			// A missing option is reported as an empty setting map so that the next apply adds it again
+			tflog.Warn(ctx, "Audit plugin option not found in option group", map[string]interface{}{
+				"option_group_name": data.OptionGroupName.ValueString(),
+			})
+		}
+
+		settings, diags := types.MapValueFrom(ctx, types.StringType, actual)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		data.OptionSettings = settings
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RDSMySQLAuditPluginResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state RDSMySQLAuditPluginResourceModel
+
+	// Read Terraform plan and prior state data into the models
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !r.providerData.configured(&resp.Diagnostics) {
+		return
+	}
+
+	timeout, diags := data.Timeouts.Update(ctx, defaultWaitTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	// Kept unless the instance went back to a default option group and is copied again
+	data.SourceOptionGroupName = state.SourceOptionGroupName
+	data.Copied = state.Copied
+
+	r.configurePlugin(ctx, &data, timeout, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// configurePlugin adds or updates the audit plugin option in the option group of the instance. A default
+// option group is copied and the copy is attached to the instance first.
+func (r *RDSMySQLAuditPluginResource) configurePlugin(ctx context.Context, data *RDSMySQLAuditPluginResourceModel, timeout time.Duration, diags *diag.Diagnostics) {
+	// Get the cached client for the requested region
+	client := r.providerData.rdsClient(data.Region.ValueString())
+	instanceID := data.DBInstanceIdentifier.ValueString()
+
+	instance, err := describeRDSInstance(ctx, client, instanceID)
+	if err != nil {
+		diags.AddError("Error describing RDS instance", fmt.Sprintf("Could not describe RDS instance: %s", err))
+		return
+	}
+	if instance == nil {
+		diags.AddError("RDS instance not found", fmt.Sprintf("RDS instance %s not found", instanceID))
+		return
+	}
+
+	if engine := aws.ToString(instance.Engine); engine != "mysql" && engine != "mariadb" {
+		diags.AddAttributeError(path.Root("db_instance_identifier"), "Not a MySQL or MariaDB instance",
+			fmt.Sprintf("The DB instance %s is not a MySQL or MariaDB instance. Engine: %s", instanceID, engine))
+		return
+	}
+
+	group, _ := optionGroupMembership(*instance, "")
+	if group == "" {
+		diags.AddError("No option group", fmt.Sprintf("RDS instance %s has no option group", instanceID))
+		return
+	}
+	if data.SourceOptionGroupName.IsNull() || data.SourceOptionGroupName.IsUnknown() {
+		data.SourceOptionGroupName = types.StringValue(group)
+	}
+
+	// Default option groups cannot be modified, work on a copy instead
+	attach := false
+	if isDefaultOptionGroup(group) {
+		target := data.TargetOptionGroupName.ValueString()
+		if target == "" {
+			target = instanceID + "-audit"
+		}
+
+		tflog.Info(ctx, "Copying default option group", map[string]interface{}{
+			"source_option_group_name": group,
+			"target_option_group_name": target,
+		})
+
+		_, err := client.CopyOptionGroup(ctx, &rds.CopyOptionGroupInput{
+			SourceOptionGroupIdentifier:  aws.String(group),
+			TargetOptionGroupIdentifier:  aws.String(target),
+			TargetOptionGroupDescription: aws.String(fmt.Sprintf("Copy of %s with the audit plugin for %s", group, instanceID)),
+		})
+		var exists *rdstypes.OptionGroupAlreadyExistsFault
+		switch {
+		case errors.As(err, &exists):
+			tflog.Warn(ctx, "Option group already exists, reusing it", map[string]interface{}{
+				"target_option_group_name": target,
+			})
+		case err != nil:
+			diags.AddError("Error copying option group", fmt.Sprintf("Could not copy option group %s to %s: %s", group, target, err))
+			return
+		default:
+			data.Copied = types.BoolValue(true)
+		}
+
+		data.SourceOptionGroupName = types.StringValue(group)
+		group = target
+		attach = true
+	}
+	data.OptionGroupName = types.StringValue(group)
+
+	var settings map[string]string
+	if !data.OptionSettings.IsNull() && !data.OptionSettings.IsUnknown() {
+		diags.Append(data.OptionSettings.ElementsAs(ctx, &settings, false)...)
+		if diags.HasError() {
+			return
+		}
+	}
+
+	tflog.Debug(ctx, "Adding audit plugin option", map[string]interface{}{
+		"option_group_name": group,
+		"option_settings":   len(settings),
+	})
+
+	_, err = client.ModifyOptionGroup(ctx, &rds.ModifyOptionGroupInput{
+		OptionGroupName:  aws.String(group),
+		OptionsToInclude: []rdstypes.OptionConfiguration{auditPluginOptionConfiguration(settings)},
+		ApplyImmediately: aws.Bool(data.ApplyImmediately.ValueBool()),
+	})
+	if err != nil {
+		diags.AddError("Error modifying option group", fmt.Sprintf("Could not add %s to option group %s: %s", mariadbAuditPluginOption, group, err))
+		return
+	}
+
+	if attach {
+		tflog.Info(ctx, "Attaching option group to RDS instance", map[string]interface{}{
+			"db_instance_identifier": instanceID,
+			"option_group_name":      group,
+		})
+
+		_, err = client.ModifyDBInstance(ctx, &rds.ModifyDBInstanceInput{
+			DBInstanceIdentifier: aws.String(instanceID),
+			OptionGroupName:      aws.String(group),
+			ApplyImmediately:     aws.Bool(data.ApplyImmediately.ValueBool()),
+		})
+		if err != nil {
+			diags.AddError("Error modifying RDS instance", fmt.Sprintf("Could not attach option group %s: %s", group, err))
+			return
+		}
+	}
+
+	status := "pending-maintenance-apply"
+	if data.ApplyImmediately.ValueBool() {
+		tflog.Info(ctx, "Waiting for the option group membership to be in-sync")
+		if err := waitForOptionGroupInSync(ctx, client, instanceID, group, timeout); err != nil {
+			diags.AddError("Error waiting for option group", fmt.Sprintf("Could not confirm option group %s is in-sync: %s", group, err))
+			return
+		}
+		status = optionGroupInSync
+	}
+	data.MembershipStatus = types.StringValue(status)
+
+	// Set computed values
+	currentTime := time.Now().Format(time.RFC3339)
+	data.LastModifiedTime = types.StringValue(currentTime)
+}
+
+func (r *RDSMySQLAuditPluginResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// No action needed on delete - removing the plugin would stop the auditing Guardium relies on
+	// The resource will be removed from state
+}
+
+func (r *RDSMySQLAuditPluginResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("db_instance_identifier"), req, resp)
+}
+
+// isDefaultOptionGroup reports whether the option group is provided by AWS and must be copied before it is modified
+func isDefaultOptionGroup(name string) bool {
+	return strings.HasPrefix(name, defaultOptionGroupPrefix)
+}
+
+// optionGroupMembership returns the option group of the instance and its membership status. While a
+// group change is pending, the instance lists both the old group, as pending-removal or removing, and
+// the new one. The membership of preferred wins, then the first one that is not being removed.
+func optionGroupMembership(instance rdstypes.DBInstance, preferred string) (group, status string) {
+	var selected *rdstypes.OptionGroupMembership
+	for i, membership := range instance.OptionGroupMemberships {
+		if preferred != "" && aws.ToString(membership.OptionGroupName) == preferred {
+			selected = &instance.OptionGroupMemberships[i]
+			break
+		}
+		if selected == nil && !isOptionGroupRemoval(aws.ToString(membership.Status)) {
+			selected = &instance.OptionGroupMemberships[i]
+		}
+	}
+	if selected == nil && len(instance.OptionGroupMemberships) > 0 {
+		selected = &instance.OptionGroupMemberships[0]
+	}
+	if selected == nil {
+		return "", ""
+	}
+	return aws.ToString(selected.OptionGroupName), aws.ToString(selected.Status)
+}
+
+// isOptionGroupRemoval reports whether the membership status belongs to a group being detached
+func isOptionGroupRemoval(status string) bool {
+	return status == "pending-removal" || status == "removing"
+}
+
+// auditPluginOptionConfiguration builds the audit plugin option with its settings sorted by name
+func auditPluginOptionConfiguration(settings map[string]string) rdstypes.OptionConfiguration {
+	names := make([]string, 0, len(settings))
+	for name := range settings {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	option := rdstypes.OptionConfiguration{OptionName: aws.String(mariadbAuditPluginOption)}
+	for _, name := range names {
+		option.OptionSettings = append(option.OptionSettings, rdstypes.OptionSetting{
+			Name:  aws.String(name),
+			Value: aws.String(settings[name]),
+		})
+	}
+	return option
+}
+
+// optionSettingValues returns the values of the option settings named in wanted
+func optionSettingValues(option rdstypes.Option, wanted map[string]string) map[string]string {
+	values := map[string]string{}
+	for _, setting := range option.OptionSettings {
+		name := aws.ToString(setting.Name)
+		if _, ok := wanted[name]; ok && setting.Value != nil {
+			values[name] = aws.ToString(setting.Value)
+		}
+	}
+	return values
+}
+
+// describeOptionGroupOption returns the named option of an option group, or nil when the group does not include it
+func describeOptionGroupOption(ctx context.Context, client *rds.Client, group, optionName string) (*rdstypes.Option, error) {
+	result, err := client.DescribeOptionGroups(ctx, &rds.DescribeOptionGroupsInput{
+		OptionGroupName: aws.String(group),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(result.OptionGroupsList) == 0 {
+		return nil, fmt.Errorf("option group %s not found", group)
+	}
+	for _, option := range result.OptionGroupsList[0].Options {
+		if aws.ToString(option.OptionName) == optionName {
+			return &option, nil
+		}
+	}
+	return nil, nil
+}
+
+// waitForOptionGroupInSync polls the instance until its membership of the option group is in-sync
+func waitForOptionGroupInSync(ctx context.Context, client *rds.Client, instanceID, group string, timeout time.Duration) error {
+	poller := &statusPoller{
+		Resource: fmt.Sprintf("option group %s of RDS instance %s", group, instanceID),
+		Target:   []string{optionGroupInSync},
+		Failure:  []string{"failed"},
+		Timeout:  timeout,
+		Refresh: func(ctx context.Context) (string, error) {
+			instance, err := describeRDSInstance(ctx, client, instanceID)
+			if err != nil {
+				return "", err
+			}
+			if instance == nil {
+				return "", fmt.Errorf("RDS instance %s not found", instanceID)
+			}
+			current, status := optionGroupMembership(*instance, group)
+			if current != group {
+				// The instance still reports its previous option group until the change is picked up
+				return "pending-apply", nil
+			}
+			return status, nil
+		},
+	}
+
+	_, err := poller.Wait(ctx)
+	return err
+}
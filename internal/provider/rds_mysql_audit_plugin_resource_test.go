@@ -0,0 +1,87 @@
+// Copyright (c) IBM Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"maps"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	rdstypes "github.com/aws/aws-sdk-go-v2/service/rds/types"
+)
+
+func TestIsDefaultOptionGroup(t *testing.T) {
+	if !isDefaultOptionGroup("default:mysql-8-0") {
+		t.Error("expected default:mysql-8-0 to be a default option group")
+	}
+	if isDefaultOptionGroup("orders-mysql-audit") {
+		t.Error("expected a custom option group not to be a default option group")
+	}
+}
+
+func TestOptionGroupMembership(t *testing.T) {
+	if group, status := optionGroupMembership(rdstypes.DBInstance{}, ""); group != "" || status != "" {
+		t.Errorf("expected no membership, got %q %q", group, status)
+	}
+
+	instance := rdstypes.DBInstance{
+		OptionGroupMemberships: []rdstypes.OptionGroupMembership{
+			{OptionGroupName: aws.String("orders-mysql-audit"), Status: aws.String("pending-apply")},
+		},
+	}
+	if group, status := optionGroupMembership(instance, ""); group != "orders-mysql-audit" || status != "pending-apply" {
+		t.Errorf("unexpected membership %q %q", group, status)
+	}
+}
+
+func TestOptionGroupMembershipDuringChange(t *testing.T) {
+	instance := rdstypes.DBInstance{
+		OptionGroupMemberships: []rdstypes.OptionGroupMembership{
+			{OptionGroupName: aws.String("default:mysql-8-0"), Status: aws.String("pending-removal")},
+			{OptionGroupName: aws.String("orders-mysql-audit"), Status: aws.String("pending-apply")},
+		},
+	}
+	if group, status := optionGroupMembership(instance, ""); group != "orders-mysql-audit" || status != "pending-apply" {
+		t.Errorf("expected the group being applied, got %q %q", group, status)
+	}
+
+	instance.OptionGroupMemberships[0].Status = aws.String("removing")
+	instance.OptionGroupMemberships[1].Status = aws.String("applying")
+	if group, status := optionGroupMembership(instance, "orders-mysql-audit"); group != "orders-mysql-audit" || status != "applying" {
+		t.Errorf("expected the stored group, got %q %q", group, status)
+	}
+
+	// The stored group wins even while it is being removed, so Read reports the removal
+	if group, status := optionGroupMembership(instance, "default:mysql-8-0"); group != "default:mysql-8-0" || status != "removing" {
+		t.Errorf("expected the preferred group, got %q %q", group, status)
+	}
+}
+
+func TestAuditPluginOptionConfiguration(t *testing.T) {
+	option := auditPluginOptionConfiguration(map[string]string{
+		"SERVER_AUDIT_EXCL_USERS": "rdsadmin",
+		"SERVER_AUDIT_EVENTS":     "CONNECT,QUERY_DDL",
+	})
+	if aws.ToString(option.OptionName) != mariadbAuditPluginOption {
+		t.Errorf("expected %s, got %s", mariadbAuditPluginOption, aws.ToString(option.OptionName))
+	}
+	if len(option.OptionSettings) != 2 || aws.ToString(option.OptionSettings[0].Name) != "SERVER_AUDIT_EVENTS" {
+		t.Errorf("expected the settings sorted by name, got %+v", option.OptionSettings)
+	}
+}
+
+func TestOptionSettingValues(t *testing.T) {
+	option := rdstypes.Option{
+		OptionSettings: []rdstypes.OptionSetting{
+			{Name: aws.String("SERVER_AUDIT_EVENTS"), Value: aws.String("CONNECT")},
+			{Name: aws.String("SERVER_AUDIT_EXCL_USERS")},
+			{Name: aws.String("SERVER_AUDIT_FILE_ROTATIONS"), Value: aws.String("9")},
+		},
+	}
+
+	got := optionSettingValues(option, map[string]string{"SERVER_AUDIT_EVENTS": "CONNECT,QUERY_DDL", "SERVER_AUDIT_EXCL_USERS": "rdsadmin"})
+	if want := map[string]string{"SERVER_AUDIT_EVENTS": "CONNECT"}; !maps.Equal(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
@@ -8,11 +8,12 @@ import (
 	"fmt"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
-	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/rds"
+	rdstypes "github.com/aws/aws-sdk-go-v2/service/rds/types"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
@@ -27,7 +28,7 @@ func NewRDSMySQLDataSource() datasource.DataSource {
 
 // RDSMySQLDataSource defines the data source implementation.
 type RDSMySQLDataSource struct {
-	client *rds.Client
+	providerData *providerData
 }
 
 // OptionModel represents an individual option in the option group
@@ -37,6 +38,9 @@ type MySQLOptionModel struct {
 	Permanent         types.Bool   `tfsdk:"permanent"`
 	Persistent        types.Bool   `tfsdk:"persistent"`
 	Port              types.Int64  `tfsdk:"port"`
+	OptionVersion     types.String `tfsdk:"option_version"`
+	OptionSettings    types.Map    `tfsdk:"option_settings"`
+	VpcSecurityGroups types.List   `tfsdk:"vpc_security_group_memberships"`
 }
 
 // RDSMySQLDataSourceModel describes the data source data model.
@@ -49,12 +53,15 @@ type RDSMySQLDataSourceModel struct {
 	ParameterGroup types.String `tfsdk:"parameter_group"`
 	FamilyName     types.String `tfsdk:"family_name"`
 	PGDescription  types.String `tfsdk:"parameter_group_description"`
+	Names          types.List   `tfsdk:"names"`
+	Parameters     types.List   `tfsdk:"parameters"`
 
 	// Option Group attributes
 	OptionGroup   types.String `tfsdk:"option_group"`
 	EngineName    types.String `tfsdk:"engine_name"`
 	MajorVersion  types.String `tfsdk:"major_version"`
 	OGDescription types.String `tfsdk:"option_group_description"`
+	OGStatus      types.String `tfsdk:"option_group_status"`
 	Options       types.List   `tfsdk:"options"`
 }
 
@@ -94,6 +101,8 @@ func (d *RDSMySQLDataSource) Schema(ctx context.Context, req datasource.SchemaRe
 				MarkdownDescription: "RDS MySQL parameter group description",
 				Computed:            true,
 			},
+			"names":      parameterNamesAttribute("`log_output` and `general_log`"),
+			"parameters": parametersAttribute(),
 
 			// Option Group attributes
 			"option_group": schema.StringAttribute{
@@ -112,6 +121,10 @@ func (d *RDSMySQLDataSource) Schema(ctx context.Context, req datasource.SchemaRe
 				MarkdownDescription: "RDS MySQL option group description",
 				Computed:            true,
 			},
+			"option_group_status": schema.StringAttribute{
+				MarkdownDescription: "Status of the option group membership of the instance, `in-sync` once option group changes are applied",
+				Computed:            true,
+			},
 			"options": schema.ListNestedAttribute{
 				MarkdownDescription: "List of options in the option group",
 				Computed:            true,
@@ -137,6 +150,20 @@ func (d *RDSMySQLDataSource) Schema(ctx context.Context, req datasource.SchemaRe
 							MarkdownDescription: "Port associated with the option",
 							Computed:            true,
 						},
+						"option_version": schema.StringAttribute{
+							MarkdownDescription: "Version of the option",
+							Computed:            true,
+						},
+						"option_settings": schema.MapAttribute{
+							MarkdownDescription: "Settings of the option keyed by name, settings without a value are left out",
+							ElementType:         types.StringType,
+							Computed:            true,
+						},
+						"vpc_security_group_memberships": schema.ListAttribute{
+							MarkdownDescription: "Identifiers of the VPC security groups of the option",
+							ElementType:         types.StringType,
+							Computed:            true,
+						},
 					},
 				},
 			},
@@ -152,14 +179,13 @@ func (d *RDSMySQLDataSource) Configure(ctx context.Context, req datasource.Confi
 		return
 	}
 
-	// Create AWS config and RDS client
-	awsCfg, err := config.LoadDefaultConfig(ctx)
-	if err != nil {
-		resp.Diagnostics.AddError("Unable to load AWS SDK config", fmt.Sprintf("Unable to load AWS SDK config: %s", err))
+	// Keep the provider data, RDS clients are built per region on first use
+	pd := providerDataFrom(req.ProviderData, &resp.Diagnostics)
+	if pd == nil {
 		return
 	}
 
-	d.client = rds.NewFromConfig(awsCfg)
+	d.providerData = pd
 }
 
 func (d *RDSMySQLDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -171,21 +197,13 @@ func (d *RDSMySQLDataSource) Read(ctx context.Context, req datasource.ReadReques
 		return
 	}
 
-	// If region is specified, update the AWS config
-	var client *rds.Client
-	if !data.Region.IsNull() {
-		tflog.Debug(ctx, "configuring client with region")
-		awsCfg, err := config.LoadDefaultConfig(ctx, config.WithRegion(data.Region.ValueString()))
-		if err != nil {
-			resp.Diagnostics.AddError("Unable to load AWS SDK config", fmt.Sprintf("Unable to load AWS SDK config with region %s: %s", data.Region.ValueString(), err))
-			return
-		}
-		client = rds.NewFromConfig(awsCfg)
-	} else {
-		tflog.Debug(ctx, "using default client")
-		client = d.client
+	if !d.providerData.configured(&resp.Diagnostics) {
+		return
 	}
 
+	// Get the cached client for the requested region
+	client := d.providerData.rdsClient(data.Region.ValueString())
+
 	// Get RDS DB instance information
 	input := &rds.DescribeDBInstancesInput{DBInstanceIdentifier: aws.String(data.DBIdentifier.ValueString())}
 
@@ -214,6 +232,7 @@ func (d *RDSMySQLDataSource) Read(ctx context.Context, req datasource.ReadReques
 	data.ID = types.StringValue(data.DBIdentifier.ValueString())
 
 	// Process Parameter Group information
+	data.Parameters = types.ListNull(parameterValueType)
 	if len(instance.DBParameterGroups) > 0 {
 		// Set parameter group value
 		data.ParameterGroup = types.StringValue(*instance.DBParameterGroups[0].DBParameterGroupName)
@@ -233,6 +252,22 @@ func (d *RDSMySQLDataSource) Read(ctx context.Context, req datasource.ReadReques
 		} else {
 			tflog.Warn(ctx, "No parameter group details found", map[string]interface{}{"parameter_group": *instance.DBParameterGroups[0].DBParameterGroupName})
 		}
+
+		// Read the parameter values, restricted to names when set
+		names := parameterNames(ctx, data.Names, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		parameters, err := rdsParameterValues(ctx, client, data.ParameterGroup.ValueString(), names)
+		if err != nil {
+			resp.Diagnostics.AddError("Unable to describe DB parameters", fmt.Sprintf("Error describing parameters of %s: %s", data.ParameterGroup.ValueString(), err))
+			return
+		}
+		data.Parameters = parametersListValue(ctx, parameters, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
 	} else {
 		tflog.Warn(ctx, "No parameter groups configured for this instance", map[string]interface{}{"db_identifier": data.DBIdentifier.ValueString()})
 	}
@@ -242,6 +277,7 @@ func (d *RDSMySQLDataSource) Read(ctx context.Context, req datasource.ReadReques
 		// Set option group value
 		optionGroupName := *instance.OptionGroupMemberships[0].OptionGroupName
 		data.OptionGroup = types.StringValue(optionGroupName)
+		data.OGStatus = types.StringPointerValue(instance.OptionGroupMemberships[0].Status)
 
 		// Get option group details
 		ogInput := &rds.DescribeOptionGroupsInput{
@@ -270,6 +306,9 @@ func (d *RDSMySQLDataSource) Read(ctx context.Context, req datasource.ReadReques
 					OptionDescription: types.StringValue(*option.OptionDescription),
 					Permanent:         types.BoolValue(*option.Permanent),
 					Persistent:        types.BoolValue(*option.Persistent),
+					OptionVersion:     types.StringPointerValue(option.OptionVersion),
+					OptionSettings:    optionSettingsMapValue(ctx, option, &resp.Diagnostics),
+					VpcSecurityGroups: stringListValue(ctx, optionVpcSecurityGroupIDs(option), &resp.Diagnostics),
 				}
 
 				if option.Port != nil {
@@ -284,11 +323,14 @@ func (d *RDSMySQLDataSource) Read(ctx context.Context, req datasource.ReadReques
 			// Convert options to types.List
 			optionsList, diags := types.ListValueFrom(ctx, types.ObjectType{
 				AttrTypes: map[string]attr.Type{
-					"option_name":        types.StringType,
-					"option_description": types.StringType,
-					"permanent":          types.BoolType,
-					"persistent":         types.BoolType,
-					"port":               types.Int64Type,
+					"option_name":                    types.StringType,
+					"option_description":             types.StringType,
+					"permanent":                      types.BoolType,
+					"persistent":                     types.BoolType,
+					"port":                           types.Int64Type,
+					"option_version":                 types.StringType,
+					"option_settings":                types.MapType{ElemType: types.StringType},
+					"vpc_security_group_memberships": types.ListType{ElemType: types.StringType},
 				},
 			}, options)
 
@@ -307,3 +349,25 @@ func (d *RDSMySQLDataSource) Read(ctx context.Context, req datasource.ReadReques
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
+
+// optionSettingsMapValue converts the settings of an option group option to a map keyed by setting name
+func optionSettingsMapValue(ctx context.Context, option rdstypes.Option, diags *diag.Diagnostics) types.Map {
+	settings := map[string]string{}
+	for _, setting := range option.OptionSettings {
+		if setting.Value != nil {
+			settings[aws.ToString(setting.Name)] = aws.ToString(setting.Value)
+		}
+	}
+	mapValue, d := types.MapValueFrom(ctx, types.StringType, settings)
+	diags.Append(d...)
+	return mapValue
+}
+
+// optionVpcSecurityGroupIDs returns the identifiers of the VPC security groups of an option group option
+func optionVpcSecurityGroupIDs(option rdstypes.Option) []string {
+	var ids []string
+	for _, membership := range option.VpcSecurityGroupMemberships {
+		ids = append(ids, aws.ToString(membership.VpcSecurityGroupId))
+	}
+	return ids
+}
@@ -0,0 +1,52 @@
+// Copyright (c) IBM Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	rdstypes "github.com/aws/aws-sdk-go-v2/service/rds/types"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestOptionSettingsMapValue(t *testing.T) {
+	var diags diag.Diagnostics
+	option := rdstypes.Option{
+		OptionSettings: []rdstypes.OptionSetting{
+			{Name: aws.String("SERVER_AUDIT_EVENTS"), Value: aws.String("CONNECT,QUERY_DDL")},
+			{Name: aws.String("SERVER_AUDIT_EXCL_USERS")},
+		},
+	}
+
+	settings := optionSettingsMapValue(context.Background(), option, &diags)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	elements := settings.Elements()
+	if len(elements) != 1 {
+		t.Fatalf("expected one setting, got %v", elements)
+	}
+	if got := elements["SERVER_AUDIT_EVENTS"].(types.String).ValueString(); got != "CONNECT,QUERY_DDL" {
+		t.Errorf("expected CONNECT,QUERY_DDL, got %s", got)
+	}
+}
+
+func TestOptionVpcSecurityGroupIDs(t *testing.T) {
+	option := rdstypes.Option{
+		VpcSecurityGroupMemberships: []rdstypes.VpcSecurityGroupMembership{
+			{VpcSecurityGroupId: aws.String("sg-0123"), Status: aws.String("active")},
+			{VpcSecurityGroupId: aws.String("sg-4567"), Status: aws.String("active")},
+		},
+	}
+	if got, want := optionVpcSecurityGroupIDs(option), []string{"sg-0123", "sg-4567"}; !slices.Equal(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+	if got := optionVpcSecurityGroupIDs(rdstypes.Option{}); got != nil {
+		t.Errorf("expected no security groups, got %v", got)
+	}
+}
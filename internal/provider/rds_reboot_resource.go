@@ -5,12 +5,15 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
-	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/rds"
+	rdstypes "github.com/aws/aws-sdk-go-v2/service/rds/types"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -23,6 +26,7 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces
 var _ resource.Resource = &RDSRebootResource{}
 var _ resource.ResourceWithImportState = &RDSRebootResource{}
+var _ resource.ResourceWithModifyPlan = &RDSRebootResource{}
 
 func NewRDSRebootResource() resource.Resource {
 	return &RDSRebootResource{}
@@ -30,16 +34,20 @@ func NewRDSRebootResource() resource.Resource {
 
 // RDSRebootResource defines the resource implementation.
 type RDSRebootResource struct {
-	client *rds.Client
+	providerData *providerData
 }
 
 // RDSRebootResourceModel describes the resource data model.
 type RDSRebootResourceModel struct {
-	DBInstanceIdentifier types.String `tfsdk:"db_instance_identifier"`
-	Region               types.String `tfsdk:"region"`
-	ForceFailover        types.Bool   `tfsdk:"force_failover"`
-	LastRebootTime       types.String `tfsdk:"last_reboot_time"`
-	ID                   types.String `tfsdk:"id"`
+	DBInstanceIdentifier types.String   `tfsdk:"db_instance_identifier"`
+	Region               types.String   `tfsdk:"region"`
+	ForceFailover        types.Bool     `tfsdk:"force_failover"`
+	OnlyIfPendingReboot  types.Bool     `tfsdk:"only_if_pending_reboot"`
+	LastRebootTime       types.String   `tfsdk:"last_reboot_time"`
+	RebootRequired       types.Bool     `tfsdk:"reboot_required"`
+	PendingModifications types.Bool     `tfsdk:"pending_modifications"`
+	ID                   types.String   `tfsdk:"id"`
+	Timeouts             timeouts.Value `tfsdk:"timeouts"`
 }
 
 func (r *RDSRebootResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -67,10 +75,22 @@ func (r *RDSRebootResource) Schema(ctx context.Context, req resource.SchemaReque
 				MarkdownDescription: "When true, the reboot is conducted through a MultiAZ failover",
 				Optional:            true,
 			},
+			"only_if_pending_reboot": schema.BoolAttribute{
+				MarkdownDescription: "When true, the instance is only rebooted when one of its parameter groups is in `pending-reboot` state, for example after a modify that turned out to apply dynamically",
+				Optional:            true,
+			},
 			"last_reboot_time": schema.StringAttribute{
 				MarkdownDescription: "Timestamp of the last reboot operation",
 				Computed:            true,
 			},
+			"reboot_required": schema.BoolAttribute{
+				MarkdownDescription: "Whether a parameter group of the instance is in `pending-reboot` state. When true, the next plan reboots the instance again",
+				Computed:            true,
+			},
+			"pending_modifications": schema.BoolAttribute{
+				MarkdownDescription: "Whether the instance reports pending modified values, such as an instance class or engine version change waiting for the maintenance window. Informational only, a reboot does not apply them",
+				Computed:            true,
+			},
 			"id": schema.StringAttribute{
 				Computed:            true,
 				MarkdownDescription: "Identifier of the resource",
@@ -79,6 +99,12 @@ func (r *RDSRebootResource) Schema(ctx context.Context, req resource.SchemaReque
 				},
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Update: true,
+			}),
+		},
 	}
 }
 
@@ -90,14 +116,13 @@ func (r *RDSRebootResource) Configure(ctx context.Context, req resource.Configur
 		return
 	}
 
-	// Create AWS config and RDS client
-	awsCfg, err := config.LoadDefaultConfig(ctx)
-	if err != nil {
-		resp.Diagnostics.AddError("Unable to load AWS SDK config", fmt.Sprintf("Unable to load AWS SDK config: %s", err))
+	// Keep the provider data, RDS clients are built per region on first use
+	pd := providerDataFrom(req.ProviderData, &resp.Diagnostics)
+	if pd == nil {
 		return
 	}
 
-	r.client = rds.NewFromConfig(awsCfg)
+	r.providerData = pd
 }
 
 func (r *RDSRebootResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -109,59 +134,27 @@ func (r *RDSRebootResource) Create(ctx context.Context, req resource.CreateReque
 		return
 	}
 
-	// If region is specified, update the AWS config
-	var client *rds.Client
-	if !data.Region.IsNull() {
-		tflog.Debug(ctx, "configuring client with region")
-		awsCfg, err := config.LoadDefaultConfig(ctx, config.WithRegion(data.Region.ValueString()))
-		if err != nil {
-			resp.Diagnostics.AddError("Unable to load AWS SDK config", fmt.Sprintf("Unable to load AWS SDK config with region %s: %s", data.Region.ValueString(), err))
-			return
-		}
-		client = rds.NewFromConfig(awsCfg)
-	} else {
-		tflog.Debug(ctx, "using default client")
-		client = r.client
-	}
-
-	// Prepare reboot input
-	input := &rds.RebootDBInstanceInput{
-		DBInstanceIdentifier: aws.String(data.DBInstanceIdentifier.ValueString()),
-	}
-
-	// Set force failover if specified
-	if !data.ForceFailover.IsNull() {
-		input.ForceFailover = aws.Bool(data.ForceFailover.ValueBool())
+	if !r.providerData.configured(&resp.Diagnostics) {
+		return
 	}
 
-	tflog.Debug(ctx, "Rebooting RDS instance", map[string]interface{}{
-		"db_instance_identifier": data.DBInstanceIdentifier.ValueString(),
-		"force_failover":         data.ForceFailover.ValueBool(),
-	})
-
-	// Reboot the RDS instance
-	_, err := client.RebootDBInstance(ctx, input)
-	if err != nil {
-		resp.Diagnostics.AddError("Error rebooting RDS instance", fmt.Sprintf("Could not reboot RDS instance: %s", err))
+	timeout, diags := data.Timeouts.Create(ctx, defaultWaitTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	// Wait for the instance to become available again
-	waiter := rds.NewDBInstanceAvailableWaiter(client)
-	waitInput := &rds.DescribeDBInstancesInput{
-		DBInstanceIdentifier: aws.String(data.DBInstanceIdentifier.ValueString()),
-	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
 
-	tflog.Info(ctx, "Waiting for RDS instance to become available")
-	err = waiter.Wait(ctx, waitInput, 30*time.Minute)
-	if err != nil {
-		resp.Diagnostics.AddError("Error waiting for RDS instance to become available", fmt.Sprintf("Could not confirm RDS instance availability: %s", err))
+	// Stays null when the reboot is skipped
+	data.LastRebootTime = types.StringNull()
+
+	r.reboot(ctx, &data, timeout, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	// Set computed values
-	currentTime := time.Now().Format(time.RFC3339)
-	data.LastRebootTime = types.StringValue(currentTime)
 	data.ID = types.StringValue(data.DBInstanceIdentifier.ValueString())
 
 	// Save data into Terraform state
@@ -177,58 +170,118 @@ func (r *RDSRebootResource) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 
-	// If region is specified, update the AWS config
-	var client *rds.Client
-	if !data.Region.IsNull() {
-		tflog.Debug(ctx, "configuring client with region")
-		awsCfg, err := config.LoadDefaultConfig(ctx, config.WithRegion(data.Region.ValueString()))
-		if err != nil {
-			resp.Diagnostics.AddError("Unable to load AWS SDK config", fmt.Sprintf("Unable to load AWS SDK config with region %s: %s", data.Region.ValueString(), err))
-			return
-		}
-		client = rds.NewFromConfig(awsCfg)
-	} else {
-		tflog.Debug(ctx, "using default client")
-		client = r.client
+	if !r.providerData.configured(&resp.Diagnostics) {
+		return
 	}
 
-	// Check if the RDS instance exists
-	input := &rds.DescribeDBInstancesInput{
-		DBInstanceIdentifier: aws.String(data.DBInstanceIdentifier.ValueString()),
-	}
+	// Get the cached client for the requested region
+	client := r.providerData.rdsClient(data.Region.ValueString())
 
-	_, err := client.DescribeDBInstances(ctx, input)
+	// Check if the RDS instance exists and whether it is waiting for a reboot
+	instance, err := describeRDSInstance(ctx, client, data.DBInstanceIdentifier.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Error reading RDS instance", fmt.Sprintf("Could not read RDS instance: %s", err))
 		return
 	}
 
+	if instance == nil {
+		tflog.Warn(ctx, "RDS instance not found, removing reboot resource from state", map[string]interface{}{
+			"db_instance_identifier": data.DBInstanceIdentifier.ValueString(),
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	pending := rdsInstancePendingReboot(*instance)
+	data.RebootRequired = types.BoolValue(pending.required())
+	data.PendingModifications = types.BoolValue(pending.Modifications)
+
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *RDSRebootResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var data RDSRebootResourceModel
+	var data, state RDSRebootResourceModel
 
-	// Read Terraform plan data into the model
+	// Read Terraform plan and prior state data into the models
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !r.providerData.configured(&resp.Diagnostics) {
+		return
+	}
+
+	timeout, diags := data.Timeouts.Update(ctx, defaultWaitTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	// Kept when the reboot is skipped
+	data.LastRebootTime = state.LastRebootTime
+
+	r.reboot(ctx, &data, timeout, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// ModifyPlan plans a reboot when the last refresh found changes waiting for one
+func (r *RDSRebootResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to compare on create or destroy
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var state, plan RDSRebootResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	// If region is specified, update the AWS config
-	var client *rds.Client
-	if !data.Region.IsNull() {
-		tflog.Debug(ctx, "configuring client with region")
-		awsCfg, err := config.LoadDefaultConfig(ctx, config.WithRegion(data.Region.ValueString()))
+	if !state.RebootRequired.ValueBool() {
+		return
+	}
+
+	plan.RebootRequired = types.BoolUnknown()
+	plan.PendingModifications = types.BoolUnknown()
+	plan.LastRebootTime = types.StringUnknown()
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+}
+
+// reboot reboots the instance, waits for it to become available again and records the outcome in data.
+// With only_if_pending_reboot the reboot is skipped when no parameter group is pending-reboot.
+func (r *RDSRebootResource) reboot(ctx context.Context, data *RDSRebootResourceModel, timeout time.Duration, diags *diag.Diagnostics) {
+	// Get the cached client for the requested region
+	client := r.providerData.rdsClient(data.Region.ValueString())
+	if data.OnlyIfPendingReboot.ValueBool() {
+		instance, err := describeRDSInstance(ctx, client, data.DBInstanceIdentifier.ValueString())
 		if err != nil {
-			resp.Diagnostics.AddError("Unable to load AWS SDK config", fmt.Sprintf("Unable to load AWS SDK config with region %s: %s", data.Region.ValueString(), err))
+			diags.AddError("Error reading RDS instance", fmt.Sprintf("Could not read RDS instance: %s", err))
+			return
+		}
+		if instance == nil {
+			diags.AddError("RDS instance not found", fmt.Sprintf("No RDS instance found with identifier: %s", data.DBInstanceIdentifier.ValueString()))
+			return
+		}
+		if pending := rdsInstancePendingReboot(*instance); !pending.required() {
+			tflog.Info(ctx, "Skipping RDS instance reboot, no parameter group is pending-reboot", map[string]interface{}{
+				"db_instance_identifier": data.DBInstanceIdentifier.ValueString(),
+			})
+			data.RebootRequired = types.BoolValue(false)
+			data.PendingModifications = types.BoolValue(pending.Modifications)
 			return
 		}
-		client = rds.NewFromConfig(awsCfg)
-	} else {
-		tflog.Debug(ctx, "using default client")
-		client = r.client
 	}
 
 	// Prepare reboot input
@@ -249,7 +302,7 @@ func (r *RDSRebootResource) Update(ctx context.Context, req resource.UpdateReque
 	// Reboot the RDS instance
 	_, err := client.RebootDBInstance(ctx, input)
 	if err != nil {
-		resp.Diagnostics.AddError("Error rebooting RDS instance", fmt.Sprintf("Could not reboot RDS instance: %s", err))
+		diags.AddError("Error rebooting RDS instance", fmt.Sprintf("Could not reboot RDS instance: %s", err))
 		return
 	}
 
@@ -259,19 +312,29 @@ func (r *RDSRebootResource) Update(ctx context.Context, req resource.UpdateReque
 		DBInstanceIdentifier: aws.String(data.DBInstanceIdentifier.ValueString()),
 	}
 
-	tflog.Debug(ctx, "Waiting for RDS instance to become available")
-	err = waiter.Wait(ctx, waitInput, 30*time.Minute)
+	tflog.Info(ctx, "Waiting for RDS instance to become available")
+	err = waiter.Wait(ctx, waitInput, timeout)
 	if err != nil {
-		resp.Diagnostics.AddError("Error waiting for RDS instance to become available", fmt.Sprintf("Could not confirm RDS instance availability: %s", err))
+		diags.AddError("Error waiting for RDS instance to become available", fmt.Sprintf("Could not confirm RDS instance availability: %s", err))
 		return
 	}
 
+	// Changes that were pending the reboot are applied by now, confirm it
+	instance, err := describeRDSInstance(ctx, client, data.DBInstanceIdentifier.ValueString())
+	if err != nil {
+		diags.AddError("Error reading RDS instance", fmt.Sprintf("Could not read RDS instance: %s", err))
+		return
+	}
+	var pending pendingReboot
+	if instance != nil {
+		pending = rdsInstancePendingReboot(*instance)
+	}
+	data.RebootRequired = types.BoolValue(pending.required())
+	data.PendingModifications = types.BoolValue(pending.Modifications)
+
 	// Set computed values
 	currentTime := time.Now().Format(time.RFC3339)
 	data.LastRebootTime = types.StringValue(currentTime)
-
-	// Save updated data into Terraform state
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *RDSRebootResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -282,3 +345,64 @@ func (r *RDSRebootResource) Delete(ctx context.Context, req resource.DeleteReque
 func (r *RDSRebootResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	resource.ImportStatePassthroughID(ctx, path.Root("db_instance_identifier"), req, resp)
 }
+
+// describeRDSInstance returns the RDS instance with the given identifier, or nil when it does not exist
+func describeRDSInstance(ctx context.Context, client *rds.Client, instanceID string) (*rdstypes.DBInstance, error) {
+	result, err := client.DescribeDBInstances(ctx, &rds.DescribeDBInstancesInput{
+		DBInstanceIdentifier: aws.String(instanceID),
+	})
+	var notFound *rdstypes.DBInstanceNotFoundFault
+	if errors.As(err, &notFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(result.DBInstances) == 0 {
+		return nil, nil
+	}
+	return &result.DBInstances[0], nil
+}
+
+// rebootRDSInstance reboots a single instance and waits for it to become available again
+func rebootRDSInstance(ctx context.Context, client *rds.Client, instanceID string, forceFailover bool, timeout time.Duration) error {
+	tflog.Debug(ctx, "Rebooting instance", map[string]interface{}{
+		"instance_identifier": instanceID,
+		"force_failover":      forceFailover,
+	})
+
+	input := &rds.RebootDBInstanceInput{
+		DBInstanceIdentifier: aws.String(instanceID),
+	}
+	if forceFailover {
+		input.ForceFailover = aws.Bool(true)
+	}
+
+	if _, err := client.RebootDBInstance(ctx, input); err != nil {
+		return err
+	}
+	return waitForRDSInstanceAvailable(ctx, client, instanceID, timeout)
+}
+
+// waitForRDSInstanceAvailable polls the RDS instance status until it is available
+func waitForRDSInstanceAvailable(ctx context.Context, client *rds.Client, instanceID string, timeout time.Duration) error {
+	poller := &statusPoller{
+		Resource: fmt.Sprintf("RDS instance %s", instanceID),
+		Target:   []string{"available"},
+		Failure:  dbFailureStatuses,
+		Timeout:  timeout,
+		Refresh: func(ctx context.Context) (string, error) {
+			instance, err := describeRDSInstance(ctx, client, instanceID)
+			if err != nil {
+				return "", err
+			}
+			if instance == nil {
+				return "", fmt.Errorf("RDS instance %s not found", instanceID)
+			}
+			return aws.ToString(instance.DBInstanceStatus), nil
+		},
+	}
+
+	_, err := poller.Wait(ctx)
+	return err
+}
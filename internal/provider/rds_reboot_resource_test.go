@@ -0,0 +1,71 @@
+// Copyright (c) IBM Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// modifyRDSRebootPlan runs ModifyPlan for an unchanged configuration, with the given state as prior state and plan
+func modifyRDSRebootPlan(t *testing.T, data RDSRebootResourceModel) RDSRebootResourceModel {
+	t.Helper()
+	ctx := context.Background()
+	r := NewRDSRebootResource().(*RDSRebootResource)
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	state := tfsdk.State{Schema: schemaResp.Schema}
+	if diags := state.Set(ctx, &data); diags.HasError() {
+		t.Fatalf("unable to set state: %v", diags)
+	}
+	plan := tfsdk.Plan{Schema: schemaResp.Schema, Raw: state.Raw.Copy()}
+
+	req := resource.ModifyPlanRequest{State: state, Plan: plan}
+	resp := resource.ModifyPlanResponse{Plan: plan}
+	r.ModifyPlan(ctx, req, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	var got RDSRebootResourceModel
+	if diags := resp.Plan.Get(ctx, &got); diags.HasError() {
+		t.Fatalf("unable to read plan: %v", diags)
+	}
+	return got
+}
+
+func TestRDSRebootModifyPlan(t *testing.T) {
+	data := RDSRebootResourceModel{
+		DBInstanceIdentifier: types.StringValue("orders"),
+		Region:               types.StringNull(),
+		ForceFailover:        types.BoolNull(),
+		OnlyIfPendingReboot:  types.BoolNull(),
+		LastRebootTime:       types.StringValue("2026-10-01T00:00:00Z"),
+		RebootRequired:       types.BoolValue(false),
+		PendingModifications: types.BoolValue(true),
+		ID:                   types.StringValue("orders"),
+		Timeouts: timeouts.Value{
+			Object: types.ObjectNull(map[string]attr.Type{"create": types.StringType, "update": types.StringType}),
+		},
+	}
+
+	// A change waiting for the maintenance window does not plan a reboot
+	if got := modifyRDSRebootPlan(t, data); got.LastRebootTime.IsUnknown() || got.RebootRequired.IsUnknown() {
+		t.Errorf("expected no reboot for pending modified values, got %+v", got)
+	}
+
+	// A parameter group in pending-reboot state does
+	data.RebootRequired = types.BoolValue(true)
+	if got := modifyRDSRebootPlan(t, data); !got.LastRebootTime.IsUnknown() || !got.PendingModifications.IsUnknown() {
+		t.Errorf("expected a reboot for a pending-reboot parameter group, got %+v", got)
+	}
+}
@@ -0,0 +1,139 @@
+// Copyright (c) IBM Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// defaultWaitTimeout is used by the long-running resources when no timeouts block is configured
+const defaultWaitTimeout = 30 * time.Minute
+
+const (
+	defaultPollMinInterval      = 5 * time.Second
+	defaultPollMaxInterval      = 30 * time.Second
+	defaultPollProgressInterval = time.Minute
+)
+
+// dbFailureStatuses are RDS, Neptune and DocumentDB instance statuses that will not resolve on their own
+var dbFailureStatuses = []string{
+	"failed",
+	"incompatible-parameters",
+	"incompatible-network",
+	"inaccessible-encryption-credentials",
+	"storage-full",
+}
+
+// statusPoller polls a resource until it reaches one of the target statuses.
+// Polling backs off exponentially with jitter between MinInterval and MaxInterval
+// and stops as soon as the context is cancelled or Timeout elapses.
+type statusPoller struct {
+	// Resource describes what is being waited on, for example "Neptune cluster my-cluster"
+	Resource string
+	// Target lists the statuses that end the wait successfully
+	Target []string
+	// Failure lists the statuses that end the wait with an error
+	Failure []string
+	// Refresh returns the current status of the resource
+	Refresh func(ctx context.Context) (string, error)
+
+	Timeout          time.Duration
+	MinInterval      time.Duration
+	MaxInterval      time.Duration
+	ProgressInterval time.Duration
+}
+
+// Wait polls until the resource reaches a target status and returns the last status seen
+func (p *statusPoller) Wait(ctx context.Context) (string, error) {
+	if p.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, p.Timeout)
+		defer cancel()
+	}
+
+	minInterval := p.MinInterval
+	if minInterval <= 0 {
+		minInterval = defaultPollMinInterval
+	}
+	maxInterval := p.MaxInterval
+	if maxInterval <= 0 {
+		maxInterval = defaultPollMaxInterval
+	}
+	maxInterval = max(maxInterval, minInterval)
+	progressInterval := p.ProgressInterval
+	if progressInterval <= 0 {
+		progressInterval = defaultPollProgressInterval
+	}
+
+	start := time.Now()
+	lastProgress := start
+	interval := minInterval
+	status := ""
+
+	for {
+		var err error
+		status, err = p.Refresh(ctx)
+		if err != nil {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return status, p.contextError(ctxErr, status)
+			}
+			return status, err
+		}
+
+		if slices.Contains(p.Target, status) {
+			tflog.Debug(ctx, "Reached target status", map[string]interface{}{
+				"resource": p.Resource,
+				"status":   status,
+				"elapsed":  time.Since(start).Round(time.Second).String(),
+			})
+			return status, nil
+		}
+
+		if slices.Contains(p.Failure, status) {
+			return status, fmt.Errorf("%s reached failure status %q", p.Resource, status)
+		}
+
+		if time.Since(lastProgress) >= progressInterval {
+			tflog.Info(ctx, fmt.Sprintf("Still waiting for %s", p.Resource), map[string]interface{}{
+				"status":  status,
+				"target":  p.Target,
+				"elapsed": time.Since(start).Round(time.Second).String(),
+			})
+			lastProgress = time.Now()
+		}
+
+		timer := time.NewTimer(jitter(interval))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return status, p.contextError(ctx.Err(), status)
+		case <-timer.C:
+		}
+
+		interval = min(interval*2, maxInterval)
+	}
+}
+
+func (p *statusPoller) contextError(err error, status string) error {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return fmt.Errorf("timed out after %s waiting for %s (last status %q)", p.Timeout, p.Resource, status)
+	}
+	return fmt.Errorf("stopped waiting for %s (last status %q): %w", p.Resource, status, err)
+}
+
+// jitter spreads d by up to 20% in either direction so parallel waits do not poll in lockstep
+func jitter(d time.Duration) time.Duration {
+	spread := int64(d) / 5
+	if spread <= 0 {
+		return d
+	}
+	return d - time.Duration(spread) + time.Duration(rand.Int64N(2*spread+1))
+}
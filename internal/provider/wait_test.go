@@ -0,0 +1,102 @@
+// Copyright (c) IBM Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testPoller(statuses ...string) (*statusPoller, *int) {
+	calls := 0
+	return &statusPoller{
+		Resource:    "test cluster",
+		Target:      []string{"available"},
+		Failure:     []string{"failed"},
+		MinInterval: time.Millisecond,
+		MaxInterval: 2 * time.Millisecond,
+		Refresh: func(ctx context.Context) (string, error) {
+			status := statuses[min(calls, len(statuses)-1)]
+			calls++
+			return status, nil
+		},
+	}, &calls
+}
+
+func TestStatusPoller_Target(t *testing.T) {
+	p, calls := testPoller("modifying", "modifying", "available")
+
+	status, err := p.Wait(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if status != "available" {
+		t.Errorf("expected status available, got %q", status)
+	}
+	if *calls != 3 {
+		t.Errorf("expected 3 refreshes, got %d", *calls)
+	}
+}
+
+func TestStatusPoller_Failure(t *testing.T) {
+	p, _ := testPoller("modifying", "failed")
+
+	status, err := p.Wait(context.Background())
+	if err == nil {
+		t.Fatal("expected an error for a failure status")
+	}
+	if status != "failed" {
+		t.Errorf("expected status failed, got %q", status)
+	}
+}
+
+func TestStatusPoller_RefreshError(t *testing.T) {
+	p, _ := testPoller("modifying")
+	want := errors.New("throttled")
+	p.Refresh = func(ctx context.Context) (string, error) { return "", want }
+
+	if _, err := p.Wait(context.Background()); !errors.Is(err, want) {
+		t.Fatalf("expected refresh error, got %v", err)
+	}
+}
+
+func TestStatusPoller_Timeout(t *testing.T) {
+	p, _ := testPoller("modifying")
+	p.Timeout = 20 * time.Millisecond
+
+	_, err := p.Wait(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "timed out") {
+		t.Fatalf("expected timeout error, got %v", err)
+	}
+}
+
+func TestStatusPoller_Cancel(t *testing.T) {
+	p, _ := testPoller("modifying")
+	p.MinInterval = time.Hour
+	p.MaxInterval = time.Hour
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(10*time.Millisecond, cancel)
+
+	start := time.Now()
+	_, err := p.Wait(ctx)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected cancellation error, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("expected Wait to return promptly on cancellation, took %s", elapsed)
+	}
+}
+
+func TestJitter(t *testing.T) {
+	d := 10 * time.Second
+	for i := 0; i < 100; i++ {
+		if got := jitter(d); got < 8*time.Second || got > 12*time.Second {
+			t.Fatalf("jitter(%s) = %s, outside of +/-20%%", d, got)
+		}
+	}
+}